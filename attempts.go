@@ -0,0 +1,53 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooManyAttempts is matched by the *ValidationError returned when a
+// prompt runs out of attempts.
+var ErrTooManyAttempts = errors.New("prompt: too many attempts")
+
+// WithMaxAttempts limits how many times a question is asked when the input
+// is missing or fails a check. Once the limit is reached, a *ValidationError
+// is returned. Zero, the default, asks until the input is valid.
+func WithMaxAttempts(n int) Option {
+	return func(q *prompt) {
+		q.maxAttempts = n
+	}
+}
+
+// ValidationError is returned when a prompt runs out of attempts. It wraps
+// the error from the last attempt and matches ErrTooManyAttempts.
+type ValidationError struct {
+	Prompt   string // question that was asked
+	Attempts int
+	Err      error // error from the last attempt
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("prompt: %q failed after %d attempts: %v", e.Prompt, e.Attempts, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// failAttempt counts a failed attempt and returns a *ValidationError once
+// the limit has been reached. Once the input has ended, there's nothing left
+// to retry with, so it returns ErrEOF.
+func (q *prompt) failAttempt(prompt string, attempts *int, err error) error {
+	if q.eof {
+		return fmt.Errorf("%w: %w", ErrEOF, err)
+	}
+	*attempts++
+	if q.maxAttempts > 0 && *attempts >= q.maxAttempts {
+		return &ValidationError{Prompt: prompt, Attempts: *attempts, Err: err}
+	}
+	return nil
+}
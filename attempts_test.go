@@ -0,0 +1,87 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+var errNotANumber = errors.New("not a number")
+
+func isNumber(s string) error {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return errNotANumber
+		}
+	}
+	return nil
+}
+
+func TestAskMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+
+	_, err := prompt.Ask(ctx, "Age?",
+		prompt.WithReader(bytes.NewBufferString("old\n\nten\n42\n")),
+		prompt.WithWriter(writer),
+		prompt.WithCheck(isNumber),
+		prompt.WithMaxAttempts(3),
+	)
+	is.True(errors.Is(err, prompt.ErrTooManyAttempts))
+	is.True(errors.Is(err, errNotANumber))
+	var validationErr *prompt.ValidationError
+	is.True(errors.As(err, &validationErr))
+	is.Equal(validationErr.Prompt, "Age?")
+	is.Equal(validationErr.Attempts, 3)
+	is.Equal(validationErr.Err, errNotANumber)
+	is.Equal(writer.String(), "Age? not a number\nAge? Age? not a number\n")
+}
+
+func TestAskMaxAttemptsRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	_, err := prompt.Ask(ctx, "Name?",
+		prompt.WithReader(bytes.NewBufferString("\n\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithMaxAttempts(2),
+	)
+	is.True(errors.Is(err, prompt.ErrTooManyAttempts))
+	is.True(errors.Is(err, prompt.ErrRequired))
+}
+
+func TestAskMaxAttemptsSucceeds(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	age, err := prompt.Ask(ctx, "Age?",
+		prompt.WithReader(bytes.NewBufferString("old\n42\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithCheck(isNumber),
+		prompt.WithMaxAttempts(2),
+	)
+	is.NoErr(err)
+	is.Equal(age, "42")
+}
+
+func TestPasswordMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	_, err := prompt.Password(ctx, "PIN?",
+		prompt.WithReader(bytes.NewBufferString("abcd\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithCheck(isNumber),
+		prompt.WithMaxAttempts(1),
+	)
+	var validationErr *prompt.ValidationError
+	is.True(errors.As(err, &validationErr))
+	is.Equal(validationErr.Attempts, 1)
+	is.Equal(err.Error(), `prompt: "PIN?" failed after 1 attempts: not a number`)
+}
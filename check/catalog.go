@@ -0,0 +1,98 @@
+package check
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Message keys used by the checks. Use them to translate messages with
+// SetCatalog.
+const (
+	MsgMinLen     = "min-len"
+	MsgMaxLen     = "max-len"
+	MsgRegexp     = "regexp"
+	MsgOneOf      = "one-of"
+	MsgEmail      = "email"
+	MsgURL        = "url"
+	MsgPort       = "port"
+	MsgInt        = "int"
+	MsgIntRange   = "int-range"
+	MsgFileExists = "file-exists"
+	MsgNotFile    = "not-file"
+	MsgDirExists  = "dir-exists"
+	MsgNotDir     = "not-dir"
+	MsgHostname   = "hostname"
+	MsgSemVer     = "semver"
+	MsgJSON       = "json"
+	MsgNot        = "not"
+	MsgAny        = "any"
+	MsgAnyEmpty   = "any-empty"
+)
+
+// Catalog maps message keys to fmt templates.
+type Catalog map[string]string
+
+// DefaultCatalog holds the English messages.
+var DefaultCatalog = Catalog{
+	MsgMinLen:     "must be at least %d characters",
+	MsgMaxLen:     "must be at most %d characters",
+	MsgRegexp:     "must match %s",
+	MsgOneOf:      "must be one of %s",
+	MsgEmail:      "must be a valid email address",
+	MsgURL:        "must be a valid URL",
+	MsgPort:       "must be a port between 1 and 65535",
+	MsgInt:        "must be a whole number",
+	MsgIntRange:   "must be between %d and %d",
+	MsgFileExists: "file %s does not exist",
+	MsgNotFile:    "%s is not a file",
+	MsgDirExists:  "directory %s does not exist",
+	MsgNotDir:     "%s is not a directory",
+	MsgHostname:   "must be a valid hostname",
+	MsgSemVer:     "must be a semantic version like 1.2.3",
+	MsgJSON:       "must be valid JSON",
+	MsgNot:        "is not allowed",
+	MsgAny:        "%s or %s",
+	MsgAnyEmpty:   "no check can pass",
+}
+
+var (
+	mu      sync.RWMutex
+	catalog = Catalog{}
+)
+
+// SetCatalog replaces the messages used by the checks. Keys missing from c
+// fall back to DefaultCatalog.
+func SetCatalog(c Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+	catalog = Catalog{}
+	for key, template := range c {
+		catalog[key] = template
+	}
+}
+
+// message formats the message for key from the current catalog.
+func message(key string, args ...any) string {
+	mu.RLock()
+	template, ok := catalog[key]
+	mu.RUnlock()
+	if !ok {
+		template = DefaultCatalog[key]
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// Error is returned by failing checks. The message is looked up in the
+// catalog when it's formatted.
+type Error struct {
+	Key  string
+	Args []any
+}
+
+func (e *Error) Error() string {
+	return message(e.Key, e.Args...)
+}
+
+func fail(key string, args ...any) error {
+	return &Error{Key: key, Args: args}
+}
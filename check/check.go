@@ -0,0 +1,235 @@
+// Package check provides common checks for prompt.WithCheck.
+package check
+
+import (
+	"encoding/json"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Func checks an input and returns an error describing what's wrong.
+type Func = func(input string) error
+
+// MinLen requires at least n characters.
+func MinLen(n int) Func {
+	return func(input string) error {
+		if utf8.RuneCountInString(input) < n {
+			return fail(MsgMinLen, n)
+		}
+		return nil
+	}
+}
+
+// MaxLen allows at most n characters.
+func MaxLen(n int) Func {
+	return func(input string) error {
+		if utf8.RuneCountInString(input) > n {
+			return fail(MsgMaxLen, n)
+		}
+		return nil
+	}
+}
+
+// Regexp requires the input to match pattern. It panics if pattern doesn't
+// compile.
+func Regexp(pattern string) Func {
+	re := regexp.MustCompile(pattern)
+	return func(input string) error {
+		if !re.MatchString(input) {
+			return fail(MsgRegexp, pattern)
+		}
+		return nil
+	}
+}
+
+// OneOf requires the input to be one of values.
+func OneOf(values ...string) Func {
+	return func(input string) error {
+		for _, value := range values {
+			if input == value {
+				return nil
+			}
+		}
+		return fail(MsgOneOf, strings.Join(values, ", "))
+	}
+}
+
+// Email requires a bare email address like gopher@example.com.
+func Email() Func {
+	return func(input string) error {
+		address, err := mail.ParseAddress(input)
+		if err != nil || address.Address != input {
+			return fail(MsgEmail)
+		}
+		return nil
+	}
+}
+
+// URL requires an absolute URL with a scheme and host.
+func URL() Func {
+	return func(input string) error {
+		u, err := url.Parse(input)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fail(MsgURL)
+		}
+		return nil
+	}
+}
+
+// Port requires a TCP or UDP port number.
+func Port() Func {
+	return func(input string) error {
+		n, err := strconv.Atoi(input)
+		if err != nil || n < 1 || n > 65535 {
+			return fail(MsgPort)
+		}
+		return nil
+	}
+}
+
+// IntRange requires a whole number between min and max, inclusive.
+func IntRange(min, max int) Func {
+	return func(input string) error {
+		n, err := strconv.Atoi(input)
+		if err != nil {
+			return fail(MsgInt)
+		}
+		if n < min || n > max {
+			return fail(MsgIntRange, min, max)
+		}
+		return nil
+	}
+}
+
+// FileExists requires the path of an existing file.
+func FileExists() Func {
+	return func(input string) error {
+		info, err := os.Stat(input)
+		if err != nil {
+			return fail(MsgFileExists, input)
+		}
+		if info.IsDir() {
+			return fail(MsgNotFile, input)
+		}
+		return nil
+	}
+}
+
+// DirExists requires the path of an existing directory.
+func DirExists() Func {
+	return func(input string) error {
+		info, err := os.Stat(input)
+		if err != nil {
+			return fail(MsgDirExists, input)
+		}
+		if !info.IsDir() {
+			return fail(MsgNotDir, input)
+		}
+		return nil
+	}
+}
+
+// Hostname requires an RFC 1123 hostname like api.example.com.
+func Hostname() Func {
+	return func(input string) error {
+		name := strings.TrimSuffix(input, ".")
+		if name == "" || len(name) > 253 {
+			return fail(MsgHostname)
+		}
+		for _, label := range strings.Split(name, ".") {
+			if !isLabel(label) {
+				return fail(MsgHostname)
+			}
+		}
+		return nil
+	}
+}
+
+func isLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+var semver = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// SemVer requires a semantic version like 1.2.3 or v1.2.3-rc.1.
+func SemVer() Func {
+	return func(input string) error {
+		if !semver.MatchString(input) {
+			return fail(MsgSemVer)
+		}
+		return nil
+	}
+}
+
+// JSON requires valid JSON.
+func JSON() Func {
+	return func(input string) error {
+		if !json.Valid([]byte(input)) {
+			return fail(MsgJSON)
+		}
+		return nil
+	}
+}
+
+// All requires every check to pass and returns the first error.
+func All(checks ...Func) Func {
+	return func(input string) error {
+		for _, check := range checks {
+			if err := check(input); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Any requires at least one check to pass. If none do, the errors are
+// combined. With no checks, every input fails, so a misconfigured Any
+// doesn't silently accept everything.
+func Any(checks ...Func) Func {
+	return func(input string) error {
+		if len(checks) == 0 {
+			return fail(MsgAnyEmpty)
+		}
+		var failure error
+		for _, check := range checks {
+			err := check(input)
+			if err == nil {
+				return nil
+			}
+			if failure == nil {
+				failure = err
+			} else {
+				failure = fail(MsgAny, failure, err)
+			}
+		}
+		return failure
+	}
+}
+
+// Not requires check to fail.
+func Not(check Func) Func {
+	return func(input string) error {
+		if check(input) == nil {
+			return fail(MsgNot)
+		}
+		return nil
+	}
+}
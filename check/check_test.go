@@ -0,0 +1,106 @@
+package check_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+	"github.com/matthewmueller/prompt/check"
+)
+
+func TestChecks(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		check check.Func
+		input string
+		err   string
+	}{
+		{"min len", check.MinLen(3), "héé", ""},
+		{"min len short", check.MinLen(3), "hé", "must be at least 3 characters"},
+		{"max len", check.MaxLen(2), "abc", "must be at most 2 characters"},
+		{"regexp", check.Regexp(`^[a-z]+$`), "abc", ""},
+		{"regexp fail", check.Regexp(`^[a-z]+$`), "ABC", "must match ^[a-z]+$"},
+		{"one of", check.OneOf("dev", "prod"), "prod", ""},
+		{"one of fail", check.OneOf("dev", "prod"), "test", "must be one of dev, prod"},
+		{"email", check.Email(), "gopher@example.com", ""},
+		{"email name", check.Email(), "Gopher <gopher@example.com>", "must be a valid email address"},
+		{"url", check.URL(), "https://example.com/path", ""},
+		{"url relative", check.URL(), "/path", "must be a valid URL"},
+		{"port", check.Port(), "8080", ""},
+		{"port zero", check.Port(), "0", "must be a port between 1 and 65535"},
+		{"int range", check.IntRange(1, 10), "10", ""},
+		{"int range high", check.IntRange(1, 10), "11", "must be between 1 and 10"},
+		{"int range nan", check.IntRange(1, 10), "ten", "must be a whole number"},
+		{"file", check.FileExists(), file, ""},
+		{"file dir", check.FileExists(), dir, dir + " is not a file"},
+		{"file missing", check.FileExists(), file + ".x", "file " + file + ".x does not exist"},
+		{"dir", check.DirExists(), dir, ""},
+		{"dir file", check.DirExists(), file, file + " is not a directory"},
+		{"hostname", check.Hostname(), "api.example.com", ""},
+		{"hostname dash", check.Hostname(), "-api.example.com", "must be a valid hostname"},
+		{"hostname underscore", check.Hostname(), "a_b.com", "must be a valid hostname"},
+		{"semver", check.SemVer(), "v1.2.3-rc.1+build.5", ""},
+		{"semver short", check.SemVer(), "1.2", "must be a semantic version like 1.2.3"},
+		{"json", check.JSON(), `{"a": [1, 2]}`, ""},
+		{"json fail", check.JSON(), `{"a":`, "must be valid JSON"},
+		{"all", check.All(check.MinLen(2), check.MaxLen(4)), "abc", ""},
+		{"all fail", check.All(check.MinLen(2), check.MaxLen(4)), "abcde", "must be at most 4 characters"},
+		{"any", check.Any(check.Email(), check.Hostname()), "example.com", ""},
+		{"any fail", check.Any(check.Email(), check.Port()), "x", "must be a valid email address or must be a port between 1 and 65535"},
+		{"any empty", check.Any(), "anything", "no check can pass"},
+		{"all empty", check.All(), "anything", ""},
+		{"not", check.Not(check.OneOf("root")), "admin", ""},
+		{"not fail", check.Not(check.OneOf("root")), "root", "is not allowed"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			err := test.check(test.input)
+			if test.err == "" {
+				is.NoErr(err)
+				return
+			}
+			is.True(err != nil)
+			is.Equal(err.Error(), test.err)
+		})
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	is := is.New(t)
+	check.SetCatalog(check.Catalog{
+		check.MsgMinLen: "doit contenir au moins %d caractères",
+	})
+	defer check.SetCatalog(nil)
+
+	err := check.MinLen(3)("ab")
+	is.Equal(err.Error(), "doit contenir au moins 3 caractères")
+	is.Equal(check.Port()("x").Error(), "must be a port between 1 and 65535")
+
+	var checkErr *check.Error
+	is.True(errors.As(err, &checkErr))
+	is.Equal(checkErr.Key, check.MsgMinLen)
+}
+
+func TestWithCheck(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	port, err := prompt.Ask(context.Background(), "Port?",
+		prompt.WithReader(bytes.NewBufferString("http\n8080\n")),
+		prompt.WithWriter(writer),
+		prompt.WithCheck(check.Port()),
+	)
+	is.NoErr(err)
+	is.Equal(port, "8080")
+	is.Equal(writer.String(), "Port? must be a port between 1 and 65535\nPort? ")
+}
@@ -0,0 +1,106 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ctxFn func(context.Context, string) error
+
+// WithCheckContext appends checks that receive a context, for checks that
+// can be slow, like looking something up over the network. The context is
+// cancelled when the prompt's context ends or, with WithLiveCheck, when the
+// input changes. A spinner is shown while they run in a terminal.
+func WithCheckContext(checks ...func(ctx context.Context, input string) error) Option {
+	return func(q *prompt) {
+		for _, check := range checks {
+			q.checks = append(q.checks, check)
+		}
+		if len(checks) > 0 {
+			q.slowChecks = true
+		}
+	}
+}
+
+// skipEmpty wraps check so it doesn't run on empty input, which is only
+// accepted with WithOptional and has nothing to check.
+func skipEmpty(check func(input string) error) ctxFn {
+	return func(_ context.Context, input string) error {
+		if input == "" {
+			return nil
+		}
+		return check(input)
+	}
+}
+
+// runChecks runs the checks in order and returns the first error.
+func (q *prompt) runChecks(ctx context.Context, input string) error {
+	// A mask's slots were already filtered by maskValue.
+	if q.mask == nil {
+		if err := q.checkRunes(input); err != nil {
+			return err
+		}
+	}
+	for _, check := range q.checks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := check(ctx, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runChecksWithSpinner runs the checks after a line has been entered,
+// showing a spinner on the current row while slow checks run in a terminal.
+func (q *prompt) runChecksWithSpinner(ctx context.Context, input string) error {
+	if !q.slowChecks || !q.isTerminal() {
+		return q.runChecks(ctx, input)
+	}
+	stop := spin(func(frame string) {
+		fmt.Fprintf(q.writer, "\r%s%s Checking...%s\x1b[K", hintStart, frame, hintEnd)
+	})
+	err := q.runChecks(ctx, input)
+	if stop() {
+		fmt.Fprint(q.writer, "\r\x1b[K")
+	}
+	return err
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 80 * time.Millisecond
+
+// spin calls draw with the next spinner frame every interval, starting after
+// the first interval so fast checks don't flicker. The returned function
+// stops spinning and reports whether anything was drawn.
+func spin(draw func(frame string)) (stop func() bool) {
+	ticker := time.NewTicker(spinnerInterval)
+	return spinOn(ticker.C, ticker.Stop, draw)
+}
+
+// spinOn draws the next frame on each tick, calling release once it stops.
+func spinOn(ticks <-chan time.Time, release func(), draw func(frame string)) (stop func() bool) {
+	done := make(chan struct{})
+	drawn := make(chan bool, 1)
+	go func() {
+		frames := 0
+		for {
+			select {
+			case <-ticks:
+				draw(spinnerFrames[frames%len(spinnerFrames)])
+				frames++
+			case <-done:
+				release()
+				drawn <- frames > 0
+				return
+			}
+		}
+	}()
+	return func() bool {
+		close(done)
+		return <-drawn
+	}
+}
@@ -0,0 +1,62 @@
+package prompt
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestLiveCheckCancelledOnEdit(t *testing.T) {
+	is := is.New(t)
+	started := make(chan context.Context, 1)
+	q := newPrompt(WithLiveCheck(0), WithCheckContext(func(ctx context.Context, input string) error {
+		if input == "a" {
+			started <- ctx
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}))
+	e := newEditor(io.Discard, -1, "? ")
+	q.live = q.startLiveCheck(context.Background(), e)
+	defer q.live.stop()
+
+	e.mu.Lock()
+	e.insert('a')
+	q.live.changed()
+	e.mu.Unlock()
+	ctx := <-started
+
+	e.mu.Lock()
+	e.insert('b')
+	q.live.changed()
+	e.mu.Unlock()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("check wasn't cancelled")
+	}
+	is.Equal(ctx.Err(), context.Canceled)
+}
+
+func TestSpin(t *testing.T) {
+	is := is.New(t)
+	ticks := make(chan time.Time)
+	var frames []string
+	released := false
+	stop := spinOn(ticks, func() { released = true }, func(frame string) {
+		frames = append(frames, frame)
+	})
+	ticks <- time.Time{}
+	ticks <- time.Time{}
+	is.True(stop())
+	is.Equal(frames, spinnerFrames[:2])
+	is.True(released)
+
+	// Nothing is drawn before the first tick.
+	stop = spinOn(make(chan time.Time), func() {}, func(string) {})
+	is.True(!stop())
+}
@@ -0,0 +1,57 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+type ctxKey struct{}
+
+func TestAskCheckContext(t *testing.T) {
+	is := is.New(t)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "db")
+	writer := new(bytes.Buffer)
+	var seen []string
+
+	name, err := prompt.Ask(ctx, "Name?",
+		prompt.WithReader(bytes.NewBufferString("taken\nfree\n")),
+		prompt.WithWriter(writer),
+		prompt.WithCheck(func(input string) error {
+			seen = append(seen, "check "+input)
+			return nil
+		}),
+		prompt.WithCheckContext(func(ctx context.Context, input string) error {
+			seen = append(seen, ctx.Value(ctxKey{}).(string)+" "+input)
+			if input == "taken" {
+				return errors.New("name is taken")
+			}
+			return nil
+		}),
+	)
+	is.NoErr(err)
+	is.Equal(name, "free")
+	is.Equal(seen, []string{"check taken", "db taken", "check free", "db free"})
+	is.Equal(writer.String(), "Name? name is taken\nName? ")
+}
+
+func TestAskCheckContextCancel(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := prompt.Ask(ctx, "Name?",
+		prompt.WithReader(bytes.NewBufferString("slow\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithCheckContext(func(ctx context.Context, input string) error {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	)
+	is.True(errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,78 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+// queryCursorColumn asks the terminal for the cursor position with a device
+// status report (ESC[6n) and returns the zero-based column. Any other input
+// that arrives first is left in the reader. It returns false when the
+// terminal doesn't answer before the timeout.
+func queryCursorColumn(w io.Writer, r *bufio.Reader, fd int, timeout time.Duration) (int, bool) {
+	if !canPoll && r.Buffered() == 0 {
+		return 0, false
+	}
+	fmt.Fprint(w, "\x1b[6n")
+	deadline := time.Now().Add(timeout)
+	for {
+		buf, err := r.Peek(r.Buffered())
+		if err != nil {
+			return 0, false
+		}
+		col, n, complete, valid := parseCursorReport(buf)
+		if complete {
+			if _, err := r.Discard(n); err != nil {
+				return 0, false
+			}
+			return col - 1, true
+		}
+		if !valid {
+			return 0, false
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 || !waitReadable(fd, remaining) {
+			return 0, false
+		}
+		if _, err := r.Peek(r.Buffered() + 1); err != nil {
+			return 0, false
+		}
+	}
+}
+
+// parseCursorReport parses a cursor position report (ESC[row;colR) at the
+// start of buf. It returns the one-based column and the length of the report
+// once complete, otherwise whether buf is still a valid prefix.
+func parseCursorReport(buf []byte) (col, n int, complete, valid bool) {
+	const (
+		stateEsc = iota
+		stateBracket
+		stateRow
+		stateCol
+	)
+	state := stateEsc
+	digits := 0
+	for i, b := range buf {
+		switch {
+		case state == stateEsc && b == 0x1b:
+			state = stateBracket
+		case state == stateBracket && b == '[':
+			state = stateRow
+		case (state == stateRow || state == stateCol) && b >= '0' && b <= '9':
+			if state == stateCol {
+				col = col*10 + int(b-'0')
+			}
+			digits++
+		case state == stateRow && b == ';' && digits > 0:
+			state = stateCol
+			digits = 0
+		case state == stateCol && b == 'R' && digits > 0:
+			return col, i + 1, true, true
+		default:
+			return 0, 0, false, false
+		}
+	}
+	return 0, 0, false, true
+}
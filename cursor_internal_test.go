@@ -0,0 +1,75 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParseCursorReport(t *testing.T) {
+	is := is.New(t)
+
+	col, n, complete, valid := parseCursorReport([]byte("\x1b[12;34Rabc"))
+	is.True(complete)
+	is.True(valid)
+	is.Equal(col, 34)
+	is.Equal(n, 8)
+
+	_, _, complete, valid = parseCursorReport([]byte("\x1b[12;3"))
+	is.True(!complete)
+	is.True(valid)
+
+	_, _, complete, valid = parseCursorReport([]byte("abc"))
+	is.True(!complete)
+	is.True(!valid)
+}
+
+func TestQueryCursorColumn(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := bufio.NewReader(strings.NewReader("\x1b[3;15Rx"))
+	_, err := reader.Peek(1)
+	is.NoErr(err)
+
+	col, ok := queryCursorColumn(writer, reader, -1, time.Millisecond)
+	is.True(ok)
+	is.Equal(col, 14)
+	is.Equal(writer.String(), "\x1b[6n")
+
+	b, err := reader.ReadByte()
+	is.NoErr(err)
+	is.Equal(b, byte('x'))
+}
+
+func TestQueryCursorColumnKeepsTypeahead(t *testing.T) {
+	is := is.New(t)
+	reader := bufio.NewReader(strings.NewReader("hi"))
+	_, err := reader.Peek(1)
+	is.NoErr(err)
+
+	_, ok := queryCursorColumn(new(bytes.Buffer), reader, -1, time.Millisecond)
+	is.True(!ok)
+
+	rest, _ := reader.ReadString('\n')
+	is.Equal(rest, "hi")
+}
+
+func TestEditorAnchor(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.layout.width = 10
+	e.width = func() int { return 10 }
+
+	e.anchor(7)
+	is.Equal(e.origin, 5)
+	is.Equal(e.inputOffset(), 7)
+
+	e.line, e.cursor = []rune("a"), 1
+	e.refresh()
+	is.Equal(writer.String(), "a\r\x1b[8C")
+}
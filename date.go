@@ -0,0 +1,425 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithDateRange limits Date and DateTime to times between min and max,
+// inclusive. A zero time leaves that end of the range open.
+func WithDateRange(min, max time.Time) Option {
+	return func(q *prompt) {
+		q.minDate, q.maxDate = min, max
+	}
+}
+
+// WithLocation sets the time zone that Date and DateTime interpret input in
+// and return times in. It defaults to time.Local.
+func WithLocation(loc *time.Location) Option {
+	return func(q *prompt) {
+		if loc == nil {
+			return
+		}
+		q.location = loc
+	}
+}
+
+// Date asks for a date and returns midnight on that day.
+func Date(ctx context.Context, prompt string, options ...Option) (time.Time, error) {
+	q := newPrompt(options...)
+	return q.Date(ctx, prompt)
+}
+
+// DateTime asks for a date and time of day.
+func DateTime(ctx context.Context, prompt string, options ...Option) (time.Time, error) {
+	q := newPrompt(options...)
+	return q.DateTime(ctx, prompt)
+}
+
+// Date asks for a date and returns midnight on that day. The input can be a
+// date like 2026-10-20, a day like today, tomorrow or friday, or an offset
+// like +3d or -2w. In a terminal, a calendar is shown below the input and
+// the arrow keys and Page Up/Down move the selected day.
+func (q *prompt) Date(ctx context.Context, prompt string) (time.Time, error) {
+	return q.date(ctx, prompt, false)
+}
+
+// DateTime asks for a date and time of day. It accepts the same input as
+// Date, optionally followed by a time like 9am or 14:30, and offsets in
+// hours (+2h) or minutes (+30m).
+func (q *prompt) DateTime(ctx context.Context, prompt string) (time.Time, error) {
+	return q.date(ctx, prompt, true)
+}
+
+func (q *prompt) date(ctx context.Context, prompt string, withTime bool) (time.Time, error) {
+	c := q.newCalendar(withTime)
+	q.calendar = c
+	q.keymap = calendarKeymap(q.keymap)
+	q.checks = append(q.checks, skipEmpty(c.check))
+	input, err := q.Ask(ctx, prompt)
+	if err != nil || input == "" {
+		return time.Time{}, err
+	}
+	return c.parse(input)
+}
+
+// calendarKeymap adapts a keymap for picking dates.
+func calendarKeymap(base *Keymap) *Keymap {
+	k := base.clone()
+	k.bindAll(ActionPreviousDay, "\x1b[D", "\x1bOD")
+	k.bindAll(ActionNextDay, "\x1b[C", "\x1bOC")
+	k.bindAll(ActionPreviousWeek, "\x1b[A", "\x1bOA")
+	k.bindAll(ActionNextWeek, "\x1b[B", "\x1bOB")
+	k.bindAll(ActionPreviousMonth, "\x1b[5~")
+	k.bindAll(ActionNextMonth, "\x1b[6~")
+	return k
+}
+
+// calendar parses dates for Date and DateTime and draws the calendar below
+// the input.
+type calendar struct {
+	withTime bool
+	loc      *time.Location
+	now      func() time.Time
+	min, max time.Time
+	selected time.Time // shown while the input isn't a valid date
+}
+
+func (q *prompt) newCalendar(withTime bool) *calendar {
+	loc := q.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := q.now
+	if now == nil {
+		now = time.Now
+	}
+	c := &calendar{withTime: withTime, loc: loc, now: now, min: q.minDate, max: q.maxDate}
+	if !withTime {
+		// Compare whole days, so any time on the first day is in range.
+		if !c.min.IsZero() {
+			c.min = startOfDay(c.min.In(loc))
+		}
+		if !c.max.IsZero() {
+			c.max = startOfDay(c.max.In(loc))
+		}
+	}
+	c.selected = c.clamp(c.truncate(now().In(loc)))
+	return c
+}
+
+// parse reads input and checks that it's within the range.
+func (c *calendar) parse(input string) (time.Time, error) {
+	t, err := parseDate(input, c.now().In(c.loc), c.withTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !c.min.IsZero() && t.Before(c.min) {
+		return time.Time{}, fmt.Errorf("must be on or after %s", c.format(c.min))
+	}
+	if !c.max.IsZero() && t.After(c.max) {
+		return time.Time{}, fmt.Errorf("must be on or before %s", c.format(c.max))
+	}
+	return t, nil
+}
+
+// check reports whether input is a valid date within the range.
+func (c *calendar) check(input string) error {
+	_, err := c.parse(input)
+	return err
+}
+
+// current returns the time the line refers to, or the selected time if it
+// doesn't parse.
+func (c *calendar) current(line string) time.Time {
+	if t, err := c.parse(line); err == nil {
+		return t
+	}
+	return c.selected
+}
+
+func (c *calendar) clamp(t time.Time) time.Time {
+	if !c.min.IsZero() && t.Before(c.min) {
+		return c.min
+	}
+	if !c.max.IsZero() && t.After(c.max) {
+		return c.max
+	}
+	return t
+}
+
+func (c *calendar) truncate(t time.Time) time.Time {
+	if c.withTime {
+		return t.Truncate(time.Minute)
+	}
+	return startOfDay(t)
+}
+
+func (c *calendar) format(t time.Time) string {
+	if c.withTime {
+		return t.In(c.loc).Format("2006-01-02 15:04")
+	}
+	return t.In(c.loc).Format("2006-01-02")
+}
+
+// inRange reports whether any time on day is within the range.
+func (c *calendar) inRange(day time.Time) bool {
+	if !c.min.IsZero() && !day.AddDate(0, 0, 1).After(c.min) {
+		return false
+	}
+	return c.max.IsZero() || !day.After(c.max)
+}
+
+// calendarWidth is the number of columns the calendar takes.
+const calendarWidth = 20
+
+// lines draws the month of the date in line, highlighting the day and
+// dimming days out of range. Nothing is drawn if the terminal is too narrow.
+func (c *calendar) lines(line string, width int) []string {
+	if width > 0 && width <= calendarWidth {
+		return nil
+	}
+	t := c.current(line).In(c.loc)
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, c.loc)
+	title := first.Format("January 2006")
+	lines := []string{
+		strings.Repeat(" ", (calendarWidth-len(title))/2) + title,
+		"Mo Tu We Th Fr Sa Su",
+	}
+	// Weeks start on Monday.
+	cells := make([]string, (int(first.Weekday())+6)%7)
+	for i := range cells {
+		cells[i] = "  "
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		switch {
+		case day.Day() == t.Day():
+			cell = "\x1b[7m" + cell + "\x1b[27m"
+		case !c.inRange(day):
+			cell = hintStart + cell + hintEnd
+		}
+		cells = append(cells, cell)
+	}
+	for len(cells) > 0 {
+		n := min(7, len(cells))
+		lines = append(lines, strings.Join(cells[:n], " "))
+		cells = cells[n:]
+	}
+	return lines
+}
+
+// moveDate moves the selected date by months and days, replacing the line
+// with it. The day of the month is kept within shorter months.
+func (e *editor) moveDate(months, days int) {
+	c := e.calendar
+	if c == nil {
+		return
+	}
+	t := c.current(string(e.line)).In(c.loc)
+	if months != 0 {
+		t = addMonths(t, months)
+	}
+	c.selected = c.clamp(t.AddDate(0, 0, days))
+	e.line = []rune(c.format(c.selected))
+	e.cursor = len(e.line)
+}
+
+// addMonths adds months to t, keeping the day within the new month.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// dateLayouts are the absolute formats accepted by parseDate.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var (
+	offsetPattern = regexp.MustCompile(`^([+-])(\d+)(mo|[mhdwy])$`)
+	clockPattern  = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+)
+
+// parseDate reads a date and optional time of day relative to now, in now's
+// location. Days like tomorrow start at midnight, while offsets like +3d
+// keep the time of day. Unless withTime is set, a time alone isn't a date
+// and the time is dropped.
+func parseDate(input string, now time.Time, withTime bool) (time.Time, error) {
+	t, err := parseDateTime(strings.TrimSpace(input), now, withTime)
+	if err != nil {
+		if withTime {
+			return time.Time{}, errors.New("must be a date and time like 2026-10-20 14:30, tomorrow 9am or +3d")
+		}
+		return time.Time{}, errors.New("must be a date like 2026-10-20, tomorrow or +3d")
+	}
+	if !withTime {
+		return startOfDay(t), nil
+	}
+	return t.Truncate(time.Minute), nil
+}
+
+func parseDateTime(input string, now time.Time, withTime bool) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return t.In(now.Location()), nil
+		}
+	}
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return time.Time{}, errInvalidDate
+	}
+	day, rest, ok := parseDay(fields, now)
+	if !ok {
+		if !withTime {
+			return time.Time{}, errInvalidDate
+		}
+		// A time alone is today.
+		day, rest = startOfDay(now), fields
+	}
+	if len(rest) > 0 && rest[0] == "at" {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		if !ok {
+			return time.Time{}, errInvalidDate
+		}
+		return day, nil
+	}
+	hour, minute, ok := parseClock(strings.Join(rest, ""))
+	if !ok {
+		return time.Time{}, errInvalidDate
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location()), nil
+}
+
+var errInvalidDate = errors.New("invalid date")
+
+// parseDay reads a day from the start of fields and returns the fields that
+// follow it.
+func parseDay(fields []string, now time.Time) (time.Time, []string, bool) {
+	today := startOfDay(now)
+	switch fields[0] {
+	case "now":
+		return now, fields[1:], true
+	case "today":
+		return today, fields[1:], true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), fields[1:], true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), fields[1:], true
+	case "next":
+		if len(fields) > 1 {
+			if day, ok := nextWeekday(fields[1], today); ok {
+				return day, fields[2:], true
+			}
+		}
+		return time.Time{}, nil, false
+	}
+	if day, ok := nextWeekday(fields[0], today); ok {
+		return day, fields[1:], true
+	}
+	if day, err := time.ParseInLocation("2006-01-02", fields[0], now.Location()); err == nil {
+		return day, fields[1:], true
+	}
+	if t, ok := parseOffset(fields[0], now); ok {
+		return t, fields[1:], true
+	}
+	return time.Time{}, nil, false
+}
+
+// nextWeekday returns the first day after today with the given name, like
+// "friday" or "fri".
+func nextWeekday(name string, today time.Time) (time.Time, bool) {
+	if len(name) < 3 {
+		return time.Time{}, false
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.HasPrefix(strings.ToLower(weekday.String()), name) {
+			days := (int(weekday)-int(today.Weekday())+6)%7 + 1
+			return today.AddDate(0, 0, days), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseOffset reads an offset from now like +3d, -2w, +1mo, +1y, +2h or
+// +30m.
+func parseOffset(s string, now time.Time) (time.Time, bool) {
+	match := offsetPattern.FindStringSubmatch(s)
+	if match == nil {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(match[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	if match[1] == "-" {
+		n = -n
+	}
+	switch match[3] {
+	case "m":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "h":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "d":
+		return now.AddDate(0, 0, n), true
+	case "w":
+		return now.AddDate(0, 0, 7*n), true
+	case "mo":
+		return addMonths(now, n), true
+	default:
+		return now.AddDate(n, 0, 0), true
+	}
+}
+
+// parseClock reads a time of day like 9am, 9:30pm, 14:30, noon or midnight.
+func parseClock(s string) (hour, minute int, ok bool) {
+	switch s {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+	match := clockPattern.FindStringSubmatch(s)
+	if match == nil {
+		return 0, 0, false
+	}
+	hour, _ = strconv.Atoi(match[1])
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if minute > 59 {
+		return 0, 0, false
+	}
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, false
+		}
+	}
+	return hour, minute, true
+}
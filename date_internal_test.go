@@ -0,0 +1,216 @@
+package prompt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// now is a Sunday afternoon.
+var now = time.Date(2026, 10, 18, 14, 37, 12, 0, time.UTC)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2026-10-20", "2026-10-20 00:00"},
+		{"2026-10-20 09:15", "2026-10-20 09:15"},
+		{"2026-10-20T09:15", "2026-10-20 09:15"},
+		{"2026-10-20T09:15:00+02:00", "2026-10-20 07:15"},
+		{"today", "2026-10-18 00:00"},
+		{"now", "2026-10-18 14:37"},
+		{"Tomorrow", "2026-10-19 00:00"},
+		{"tomorrow 9am", "2026-10-19 09:00"},
+		{"tomorrow at 9:30 pm", "2026-10-19 21:30"},
+		{"yesterday noon", "2026-10-17 12:00"},
+		{"2026-12-24 midnight", "2026-12-24 00:00"},
+		{"5pm", "2026-10-18 17:00"},
+		{"12am", "2026-10-18 00:00"},
+		{"friday", "2026-10-23 00:00"},
+		{"sun", "2026-10-25 00:00"},
+		{"next mon 10:00", "2026-10-19 10:00"},
+		{"+3d", "2026-10-21 14:37"},
+		{"-2w", "2026-10-04 14:37"},
+		{"+1mo", "2026-11-18 14:37"},
+		{"+1y", "2027-10-18 14:37"},
+		{"+2h", "2026-10-18 16:37"},
+		{"+30m", "2026-10-18 15:07"},
+		{"+3d 9am", "2026-10-21 09:00"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			is := is.New(t)
+			got, err := parseDate(test.input, now, true)
+			is.NoErr(err)
+			is.Equal(got.Format("2006-01-02 15:04"), test.want)
+		})
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	for _, input := range []string{"", "soon", "2026-13-01", "tomorrow 25:00", "13pm", "9:75", "+3x", "fr", "tomorrow later"} {
+		t.Run(input, func(t *testing.T) {
+			is := is.New(t)
+			_, err := parseDate(input, now, true)
+			is.True(err != nil)
+		})
+	}
+}
+
+func TestParseDateWithoutTime(t *testing.T) {
+	is := is.New(t)
+	got, err := parseDate("tomorrow 9am", now, false)
+	is.NoErr(err)
+	is.Equal(got, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	_, err = parseDate("soon", now, false)
+	is.Equal(err.Error(), "must be a date like 2026-10-20, tomorrow or +3d")
+
+	// A time alone needs a DateTime prompt.
+	for _, input := range []string{"9am", "14:30", "noon", "at 5pm"} {
+		_, err = parseDate(input, now, false)
+		is.Equal(err.Error(), "must be a date like 2026-10-20, tomorrow or +3d")
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	is := is.New(t)
+	jan31 := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	is.Equal(addMonths(jan31, 1), time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC))
+	is.Equal(addMonths(jan31, -2), time.Date(2025, 11, 30, 9, 0, 0, 0, time.UTC))
+	is.Equal(addMonths(jan31, 12), time.Date(2027, 1, 31, 9, 0, 0, 0, time.UTC))
+}
+
+func newTestCalendar(withTime bool, options ...Option) *calendar {
+	q := newPrompt(append([]Option{func(q *prompt) { q.now = func() time.Time { return now } }, WithLocation(time.UTC)}, options...)...)
+	return q.newCalendar(withTime)
+}
+
+func TestCalendarRange(t *testing.T) {
+	is := is.New(t)
+	// Any time on the first and last day is in range for a date.
+	c := newTestCalendar(false, WithDateRange(
+		time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 31, 9, 0, 0, 0, time.UTC),
+	))
+	_, err := c.parse("2026-10-20")
+	is.NoErr(err)
+	_, err = c.parse("2026-10-31")
+	is.NoErr(err)
+	_, err = c.parse("tomorrow")
+	is.Equal(err.Error(), "must be on or after 2026-10-20")
+	_, err = c.parse("+2w")
+	is.Equal(err.Error(), "must be on or before 2026-10-31")
+
+	// The selection starts within the range.
+	is.Equal(c.selected, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+
+	// Times are compared exactly.
+	c = newTestCalendar(true, WithDateRange(time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC), time.Time{}))
+	_, err = c.parse("2026-10-20 14:59")
+	is.Equal(err.Error(), "must be on or after 2026-10-20 15:00")
+	_, err = c.parse("2030-01-01 15:00")
+	is.NoErr(err)
+}
+
+func TestCalendarLocation(t *testing.T) {
+	is := is.New(t)
+	sydney := time.FixedZone("AEST", 10*60*60)
+	c := newTestCalendar(true, WithLocation(sydney))
+	got, err := c.parse("today 9am")
+	is.NoErr(err)
+	// It's already Monday in Sydney.
+	is.Equal(got, time.Date(2026, 10, 19, 9, 0, 0, 0, sydney))
+	is.Equal(got.Location(), sydney)
+}
+
+func TestCalendarLines(t *testing.T) {
+	is := is.New(t)
+	c := newTestCalendar(false, WithDateRange(time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), time.Time{}))
+	is.Equal(c.lines("2026-10-20", 80), []string{
+		"    October 2026",
+		"Mo Tu We Th Fr Sa Su",
+		"         \x1b[90m 1\x1b[39m \x1b[90m 2\x1b[39m  3  4",
+		" 5  6  7  8  9 10 11",
+		"12 13 14 15 16 17 18",
+		"19 \x1b[7m20\x1b[27m 21 22 23 24 25",
+		"26 27 28 29 30 31",
+	})
+
+	// An invalid line shows the selected day.
+	lines := c.lines("soon", 80)
+	is.Equal(lines[4], "12 13 14 15 16 17 \x1b[7m18\x1b[27m")
+
+	// Nothing is drawn in a narrow terminal.
+	is.Equal(len(c.lines("", 20)), 0)
+}
+
+func TestMoveDate(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.calendar = newTestCalendar(false, WithDateRange(time.Time{}, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+	// Moving starts from the selected day while the line is empty.
+	e.moveDate(0, 1)
+	is.Equal(string(e.line), "2026-10-19")
+	is.Equal(e.cursor, 10)
+	e.moveDate(0, -7)
+	is.Equal(string(e.line), "2026-10-12")
+
+	// A typed date is moved from.
+	e.line = []rune("2026-01-31")
+	e.moveDate(1, 0)
+	is.Equal(string(e.line), "2026-02-28")
+
+	// Moving stops at the end of the range.
+	e.line = []rune("2026-12-20")
+	e.moveDate(1, 0)
+	is.Equal(string(e.line), "2026-12-31")
+}
+
+func TestCalendarKeymap(t *testing.T) {
+	is := is.New(t)
+	k := calendarKeymap(defaultKeymap)
+	for key, want := range map[string]Action{
+		"\x1b[D":  ActionPreviousDay,
+		"\x1b[C":  ActionNextDay,
+		"\x1b[A":  ActionPreviousWeek,
+		"\x1b[B":  ActionNextWeek,
+		"\x1b[5~": ActionPreviousMonth,
+		"\x1b[6~": ActionNextMonth,
+	} {
+		action, ok := k.Lookup(key)
+		is.True(ok)
+		is.Equal(action, want)
+	}
+	// The default keymap is unchanged.
+	action, _ := defaultKeymap.Lookup("\x1b[D")
+	is.Equal(action, ActionBackwardChar)
+}
+
+func TestDrawPanel(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.panel = func(int) []string { return []string{"one", "two"} }
+	e.drawStatus(80, 0, 2, 0)
+	is.Equal(writer.String(), "\n\n\x1b[2A\x1b[1B\rone\x1b[1B\rtwo\x1b[J\x1b[2A\r\x1b[2C")
+
+	// Only new rows are made room for when the input grows.
+	writer.Reset()
+	e.drawStatus(80, 1, 2, 1)
+	is.Equal(writer.String(), "\n\n\x1b[2A\x1b[1B\rone\x1b[1B\rtwo\x1b[J\x1b[2A\r\x1b[2C")
+	writer.Reset()
+	e.drawStatus(80, 0, 2, 1)
+	is.Equal(writer.String(), "\x1b[2B\rone\x1b[1B\rtwo\x1b[J\x1b[3A\r\x1b[2C")
+
+	// The panel is erased when it's cleared.
+	writer.Reset()
+	e.panel = nil
+	e.drawStatus(80, 0, 2, 0)
+	is.Equal(writer.String(), "\x1b[1B\r\x1b[J\x1b[1A\r\x1b[2C")
+}
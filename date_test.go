@@ -0,0 +1,100 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestDate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	date, err := prompt.Date(ctx, "Expires?",
+		prompt.WithReader(bytes.NewBufferString("2026-10-20\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithLocation(time.UTC),
+	)
+	is.NoErr(err)
+	is.Equal(date, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+}
+
+func TestDateRejectsTime(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	date, err := prompt.Date(ctx, "Expires?",
+		prompt.WithReader(bytes.NewBufferString("9am\n2026-10-20\n")),
+		prompt.WithWriter(writer),
+		prompt.WithLocation(time.UTC),
+	)
+	is.NoErr(err)
+	is.Equal(date, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+	diff.TestString(t, writer.String(), "Expires? must be a date like 2026-10-20, tomorrow or +3d\nExpires? ")
+}
+
+func TestDateTime(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	loc := time.FixedZone("CET", 60*60)
+	start, err := prompt.DateTime(ctx, "Start?",
+		prompt.WithReader(bytes.NewBufferString("soon\n2026-10-20 9:30pm\n")),
+		prompt.WithWriter(writer),
+		prompt.WithLocation(loc),
+	)
+	is.NoErr(err)
+	is.Equal(start, time.Date(2026, 10, 20, 21, 30, 0, 0, loc))
+	diff.TestString(t, writer.String(), "Start? must be a date and time like 2026-10-20 14:30, tomorrow 9am or +3d\n"+
+		"Start? ")
+}
+
+func TestDateRange(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	date, err := prompt.Date(ctx, "Expires?",
+		prompt.WithReader(bytes.NewBufferString("2020-01-01\n2027-01-01\n2026-12-31\n")),
+		prompt.WithWriter(writer),
+		prompt.WithLocation(time.UTC),
+		prompt.WithDateRange(
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC),
+		),
+	)
+	is.NoErr(err)
+	is.Equal(date, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	diff.TestString(t, writer.String(), "Expires? must be on or after 2026-01-01\n"+
+		"Expires? must be on or before 2026-12-31\n"+
+		"Expires? ")
+}
+
+func TestDateDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	date, err := prompt.Date(ctx, "Expires?",
+		prompt.WithReader(bytes.NewBufferString("\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithDefault("2026-11-01"),
+		prompt.WithLocation(time.UTC),
+	)
+	is.NoErr(err)
+	is.Equal(date, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestDateOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	date, err := prompt.Date(ctx, "Expires?",
+		prompt.WithReader(bytes.NewBufferString("\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithOptional(true),
+	)
+	is.NoErr(err)
+	is.True(date.IsZero())
+}
@@ -0,0 +1,354 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// editor holds the state of a line being edited in raw mode along with the
+// layout that was last drawn to the terminal.
+type editor struct {
+	mu          sync.Mutex
+	w           io.Writer
+	width       func() int
+	prompt      string
+	promptWidth int
+	origin      int
+	line        []rune
+	cursor      int
+	layout      layout
+	kills       *KillRing
+	yanked      yankState
+	history     *History
+	lastArg     yankState
+	action      editAction // action of the key being handled
+	lastAction  editAction // action of the previous key
+	undos       []snapshot
+	redos       []snapshot
+	vi          viState
+	multiline   bool
+	suggest     func(line string) string
+	placeholder string
+	status      string // message shown on the status line below the input
+	statusStyle string
+	statusShown bool
+	reserved    int // last row below the prompt's first row made room for
+	allows      func(r rune) bool
+	maxLength   int
+	bell        bool
+	mask        *inputMask
+	panel       func(width int) []string // rows drawn below the status line
+	calendar    *calendar
+	numberInput *numberInput
+}
+
+// editAction classifies keys that change how the next key behaves.
+type editAction int
+
+const (
+	actOther editAction = iota
+	actInsert
+	actKill
+	actYank
+	actYankLastArg
+	actUndo
+)
+
+// snapshot is a copy of the line and cursor used for undo and redo.
+type snapshot struct {
+	line   []rune
+	cursor int
+}
+
+// yankState remembers the most recently yanked text, so Alt+Y can replace
+// it with an older kill.
+type yankState struct {
+	start, end int
+	index      int
+}
+
+// layout describes what is currently on screen, so it can be erased or
+// reflowed even after the terminal width changes.
+type layout struct {
+	width       int    // terminal width at the time of rendering
+	prompt      string // prompt text on screen
+	promptWidth int    // columns taken by the prompt
+	lineWidth   int    // columns taken by the input and any hint
+	cursor      int    // cursor column relative to the start of the input
+	cursorRow   int    // rows between the prompt and the cursor, for multi-line input
+}
+
+func newEditor(w io.Writer, fd int, prompt string) *editor {
+	e := &editor{
+		w:           w,
+		width:       func() int { return getTerminalWidth(fd) },
+		prompt:      prompt,
+		promptWidth: stringWidth([]rune(prompt)),
+		line:        []rune{},
+		kills:       NewKillRing(0),
+		statusStyle: statusStart,
+	}
+	e.resetLayout()
+	return e
+}
+
+// resetLayout records that only the prompt has been printed, starting at the
+// beginning of the current row.
+func (e *editor) resetLayout() {
+	e.origin = 0
+	e.reserved = 0
+	e.layout = layout{
+		width:       e.width(),
+		prompt:      e.prompt,
+		promptWidth: e.promptWidth,
+	}
+	if e.layout.width > 0 {
+		e.layout.cursorRow = e.promptWidth / e.layout.width
+	}
+}
+
+// setPrompt changes the prompt. It's repainted on the next refresh.
+func (e *editor) setPrompt(prompt string) {
+	e.prompt = prompt
+	e.promptWidth = stringWidth([]rune(prompt))
+}
+
+// inputOffset is the column the input starts at, counted from the start of
+// the row the prompt was printed on.
+func (e *editor) inputOffset() int {
+	return e.origin + e.promptWidth
+}
+
+// anchor sets the origin from the column the input actually starts at, as
+// reported by the terminal.
+func (e *editor) anchor(inputCol int) {
+	origin := inputCol - e.promptWidth
+	if width := e.layout.width; width > 0 {
+		origin = (origin%width + width) % width
+	}
+	if origin < 0 {
+		origin = 0
+	}
+	e.origin = origin
+}
+
+// insert inserts runes at the cursor, dropping any that aren't allowed.
+func (e *editor) insert(runes ...rune) {
+	runes, dropped := e.filter(runes)
+	if dropped && e.bell {
+		fmt.Fprint(e.w, "\a")
+	}
+	line := make([]rune, 0, len(e.line)+len(runes))
+	line = append(line, e.line[:e.cursor]...)
+	line = append(line, runes...)
+	e.line = append(line, e.line[e.cursor:]...)
+	e.cursor += len(runes)
+}
+
+// kill moves line[start:end] into the kill ring. Consecutive kills are
+// merged into a single entry.
+func (e *editor) kill(start, end int) {
+	e.action = actKill
+	if start >= end {
+		return
+	}
+	text := string(e.line[start:end])
+	if e.lastAction == actKill {
+		e.kills.extend(text, end == e.cursor)
+	} else {
+		e.kills.push(text)
+	}
+	e.line = append(e.line[:start], e.line[end:]...)
+	e.cursor = start
+}
+
+// yank inserts the most recent kill at the cursor.
+func (e *editor) yank() {
+	text, ok := e.kills.at(0)
+	if !ok {
+		return
+	}
+	start := e.cursor
+	e.insert([]rune(text)...)
+	e.yanked = yankState{start: start, end: e.cursor}
+	e.action = actYank
+}
+
+// yankPop replaces the text that was just yanked with the previous kill.
+func (e *editor) yankPop() {
+	if e.lastAction != actYank {
+		return
+	}
+	text, ok := e.kills.at(e.yanked.index + 1)
+	if !ok {
+		return
+	}
+	e.line = append(e.line[:e.yanked.start], e.line[e.yanked.end:]...)
+	e.cursor = e.yanked.start
+	e.insert([]rune(text)...)
+	e.yanked = yankState{start: e.yanked.start, end: e.cursor, index: e.yanked.index + 1}
+	e.action = actYank
+}
+
+func (e *editor) snapshot() snapshot {
+	return snapshot{line: append([]rune(nil), e.line...), cursor: e.cursor}
+}
+
+func (e *editor) restore(s snapshot) {
+	e.line = append([]rune(nil), s.line...)
+	e.cursor = s.cursor
+}
+
+// record adds an undo step if the line changed since before. Consecutive
+// character inserts are grouped into a single step.
+func (e *editor) record(before snapshot) {
+	if e.action == actUndo || string(before.line) == string(e.line) {
+		return
+	}
+	e.redos = nil
+	if e.action == actInsert && e.lastAction == actInsert && len(e.undos) > 0 {
+		return
+	}
+	e.undos = append(e.undos, before)
+}
+
+// undo reverts the last change to the line.
+func (e *editor) undo() {
+	e.action = actUndo
+	if len(e.undos) == 0 {
+		return
+	}
+	e.redos = append(e.redos, e.snapshot())
+	e.restore(e.undos[len(e.undos)-1])
+	e.undos = e.undos[:len(e.undos)-1]
+}
+
+// redo reapplies the last change that was undone.
+func (e *editor) redo() {
+	e.action = actUndo
+	if len(e.redos) == 0 {
+		return
+	}
+	e.undos = append(e.undos, e.snapshot())
+	e.restore(e.redos[len(e.redos)-1])
+	e.redos = e.redos[:len(e.redos)-1]
+}
+
+// yankLastArg inserts the last word of the previous history entry. Repeating
+// it replaces the word with the last word of older entries.
+func (e *editor) yankLastArg() {
+	if e.history == nil {
+		return
+	}
+	index := 0
+	if e.lastAction == actYankLastArg {
+		index = e.lastArg.index + 1
+	}
+	entry, ok := e.history.at(index)
+	if !ok {
+		e.action = e.lastAction
+		return
+	}
+	if e.lastAction == actYankLastArg {
+		e.line = append(e.line[:e.lastArg.start], e.line[e.lastArg.end:]...)
+		e.cursor = e.lastArg.start
+	}
+	start := e.cursor
+	e.insert([]rune(lastArg(entry))...)
+	e.lastArg = yankState{start: start, end: e.cursor, index: index}
+	e.action = actYankLastArg
+}
+
+// refresh redraws the input, reflowing the prompt if the terminal width or
+// the prompt have changed since the last render.
+func (e *editor) refresh() {
+	width := e.width()
+	if e.multiline {
+		e.renderText(width)
+		return
+	}
+	if width > 0 && e.layout.width > 0 && (width != e.layout.width || e.prompt != e.layout.prompt) {
+		e.reflow(width)
+		return
+	}
+	line, cursor := e.shown()
+	hint := e.hint()
+	redrawTerminalLine(e.w, line, hint, e.layout.lineWidth, e.layout.cursor, cursor, e.inputOffset(), width)
+	e.layout = layout{
+		width:       width,
+		prompt:      e.prompt,
+		promptWidth: e.promptWidth,
+		lineWidth:   stringWidth(line) + stringWidth(hint),
+		cursor:      cursor,
+	}
+	if width <= 0 {
+		e.drawStatus(width, 0, e.inputOffset()+cursor, 0)
+		return
+	}
+	inputCol := e.inputOffset() % width
+	cursorRow, cursorCol := visualPosition(inputCol, cursor, width)
+	endRow, _ := renderedPosition(inputCol, e.layout.lineWidth, width)
+	e.drawStatus(width, cursorRow, cursorCol, endRow)
+}
+
+// reflow erases the previous render using the layout it was drawn with, then
+// prints the prompt and input again for the new terminal width.
+func (e *editor) reflow(width int) {
+	row, _ := visualPosition(e.origin, e.layout.promptWidth+e.layout.cursor, e.layout.width)
+	if row > 0 {
+		fmt.Fprintf(e.w, "\x1b[%dA", row)
+	}
+	// Erase from the origin, keeping any text before the prompt.
+	fmt.Fprint(e.w, "\r")
+	if e.origin > 0 {
+		fmt.Fprintf(e.w, "\x1b[%dC", e.origin)
+	}
+	fmt.Fprint(e.w, "\x1b[J")
+	fmt.Fprint(e.w, e.prompt)
+	line, cursor := e.shown()
+	lineWidth := printLine(e.w, line, e.hint())
+	moveRenderedCursorToLogical(e.w, e.origin, width, e.promptWidth+lineWidth, e.promptWidth+cursor)
+	e.layout = layout{
+		width:       width,
+		prompt:      e.prompt,
+		promptWidth: e.promptWidth,
+		lineWidth:   lineWidth,
+		cursor:      cursor,
+	}
+	cursorRow, cursorCol := visualPosition(e.origin, e.promptWidth+cursor, width)
+	endRow, _ := renderedPosition(e.origin, e.promptWidth+lineWidth, width)
+	e.drawStatus(width, cursorRow, cursorCol, endRow)
+}
+
+// watchResize reflows the editor whenever the terminal is resized. The
+// returned function stops watching and waits for any pending redraw.
+func (e *editor) watchResize() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	if !notifyResize(signals) {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-signals:
+				e.mu.Lock()
+				e.refresh()
+				e.mu.Unlock()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,137 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestEditorRefresh(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.width = func() int { return 10 }
+	e.layout.width = 10
+
+	e.line, e.cursor = []rune("hi"), 2
+	e.refresh()
+
+	is.Equal(writer.String(), "hi\r\x1b[4C")
+	is.Equal(e.layout, layout{width: 10, prompt: "? ", promptWidth: 2, lineWidth: 2, cursor: 2})
+}
+
+func TestEditorReflowUsesPreviousLayout(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.line, e.cursor = []rune("abcdefghij"), 10
+	e.layout = layout{width: 5, prompt: "? ", promptWidth: 2, lineWidth: 10, cursor: 10}
+	e.width = func() int { return 20 }
+
+	e.refresh()
+
+	// The cursor was two rows below the prompt at width 5.
+	is.Equal(writer.String(), "\x1b[2A\r\x1b[J? abcdefghij\r\x1b[12C")
+	is.Equal(e.layout, layout{width: 20, prompt: "? ", promptWidth: 2, lineWidth: 10, cursor: 10})
+}
+
+func TestEditorReflowNarrower(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.line, e.cursor = []rune("abcdef"), 0
+	e.layout = layout{width: 20, prompt: "? ", promptWidth: 2, lineWidth: 6, cursor: 0}
+	e.width = func() int { return 4 }
+
+	e.refresh()
+
+	is.Equal(writer.String(), "\r\x1b[J? abcdef\x1b[1A\r\x1b[2C")
+}
+
+func TestEditorReflowKeepsTextBeforeOrigin(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.origin = 5
+	e.line, e.cursor = []rune("ab"), 2
+	e.layout = layout{width: 20, prompt: "? ", promptWidth: 2, lineWidth: 2, cursor: 2}
+	e.width = func() int { return 20 }
+	e.setPrompt("(ins) ? ")
+
+	e.refresh()
+
+	// The erase starts at the origin, not the start of the row.
+	is.Equal(writer.String(), "\r\x1b[5C\x1b[J(ins) ? ab\r\x1b[15C")
+}
+
+// typeKeys feeds keys through the editor until the input runs out.
+func typeKeys(t testing.TB, e *editor, keys string, options ...Option) *prompt {
+	t.Helper()
+	options = append([]Option{WithReader(strings.NewReader(keys)), WithWriter(io.Discard)}, options...)
+	q := newPrompt(options...)
+	for {
+		b, err := q.reader.ReadByte()
+		if err != nil {
+			return q
+		}
+		if done, _, err := q.editKey(context.Background(), e, b); done || err != nil {
+			t.Fatalf("unexpected end of editing: %v", err)
+		}
+	}
+}
+
+func TestEditorUndoGroupsInserts(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "hello world\x1f")
+	is.Equal(string(e.line), "")
+
+	typeKeys(t, e, "\x18\x12")
+	is.Equal(string(e.line), "hello world")
+	is.Equal(e.cursor, 11)
+}
+
+func TestEditorUndoKill(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+
+	// Type, move left, kill to start, undo with Ctrl+X Ctrl+U.
+	typeKeys(t, e, "https://example.com\x02\x15")
+	is.Equal(string(e.line), "m")
+
+	typeKeys(t, e, "\x18\x15")
+	is.Equal(string(e.line), "https://example.com")
+	is.Equal(e.cursor, 18)
+}
+
+func TestEditorUndoSteps(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+
+	// Insert, delete with an escape sequence, yank, then undo each step.
+	typeKeys(t, e, "abc\x01\x1b[3~\x0b\x19")
+	is.Equal(string(e.line), "bc")
+
+	typeKeys(t, e, "\x1f")
+	is.Equal(string(e.line), "")
+	typeKeys(t, e, "\x1f")
+	is.Equal(string(e.line), "bc")
+	typeKeys(t, e, "\x1f")
+	is.Equal(string(e.line), "abc")
+	typeKeys(t, e, "\x1f\x1f")
+	is.Equal(string(e.line), "")
+}
+
+func TestEditorEditClearsRedo(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "one\x1ftwo\x18\x12")
+	is.Equal(string(e.line), "two")
+	is.Equal(len(e.redos), 0)
+}
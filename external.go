@@ -0,0 +1,216 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// WithTemplate sets the initial text written to the file opened by Editor.
+func WithTemplate(template string) Option {
+	return func(q *prompt) {
+		q.template = template
+	}
+}
+
+// Editor opens $VISUAL or $EDITOR to write long-form input. Lines starting
+// with '#' are removed from the result. If a check fails, the error is shown
+// and the user can choose to reopen the editor.
+func Editor(ctx context.Context, prompt string, options ...Option) (string, error) {
+	q := newPrompt(options...)
+	return q.Editor(ctx, prompt)
+}
+
+// Editor opens $VISUAL or $EDITOR to write long-form input.
+func (q *prompt) Editor(ctx context.Context, prompt string) (string, error) {
+	text := q.template
+	attempts := 0
+	var failure error
+	for {
+		edited, err := q.openEditor(ctx, editorTemplate(text, prompt, failure))
+		if err != nil {
+			return "", err
+		}
+		// A transform error is shown like a failed check, reopening the
+		// untransformed text.
+		text = stripComments(edited)
+		var input string
+		input, failure = q.transform(text)
+		if failure == nil {
+			if input == "" {
+				if q.defaultTo != "" {
+					return q.defaultTo, nil
+				} else if q.optional {
+					return "", nil
+				}
+			}
+			failure = q.check(ctx, input)
+			if failure == nil {
+				return input, nil
+			}
+			text = input
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		fmt.Fprintln(q.writer, failure)
+		if err := q.failAttempt(prompt, &attempts, failure); err != nil {
+			return "", err
+		}
+		again, err := q.reopen(ctx)
+		if err != nil {
+			return "", err
+		}
+		if !again {
+			return "", failure
+		}
+	}
+}
+
+// check runs the checks against input from the editor.
+func (q *prompt) check(ctx context.Context, input string) error {
+	if input == "" {
+		return ErrRequired
+	}
+	return q.runChecksWithSpinner(ctx, input)
+}
+
+// reopen asks whether to open the editor again after a failed check.
+func (q *prompt) reopen(ctx context.Context) (bool, error) {
+	c := newPrompt(WithWriter(q.writer))
+	c.reader, c.fd = q.reader, q.fd
+	return c.Confirm(ctx, "Edit again? (y/n)")
+}
+
+// editorTemplate returns the file contents shown in the editor, followed by
+// the prompt and the previous error as comments.
+func editorTemplate(text, prompt string, failure error) string {
+	var b strings.Builder
+	b.WriteString(text)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	if failure != nil {
+		fmt.Fprintf(&b, "# %s\n", failure)
+	}
+	if prompt != "" {
+		fmt.Fprintf(&b, "# %s\n", prompt)
+	}
+	b.WriteString("# Lines starting with '#' will be ignored.\n")
+	return b.String()
+}
+
+// stripComments removes comment lines and surrounding blank lines.
+func stripComments(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	text = strings.Join(kept, "\n")
+	text = strings.TrimLeft(text, "\n")
+	return strings.TrimRightFunc(text, func(r rune) bool {
+		return r == '\n' || r == ' ' || r == '\t'
+	})
+}
+
+// openEditor writes text to a temporary file, waits for the user's editor to
+// exit and returns the edited contents.
+func (q *prompt) openEditor(ctx context.Context, text string) (string, error) {
+	f, err := os.CreateTemp("", "prompt-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	cmd := editorCommand(ctx, f.Name())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("prompt: unable to run editor: %w", err)
+	}
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(edited), nil
+}
+
+// editorCommand returns the command that opens path in $VISUAL or $EDITOR.
+func editorCommand(ctx context.Context, path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if runtime.GOOS == "windows" {
+		if editor == "" {
+			editor = "notepad"
+		}
+		args := append(strings.Fields(editor), path)
+		return exec.CommandContext(ctx, args[0], args[1:]...)
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// Run the editor through the shell like git does, so it can have flags.
+	return exec.CommandContext(ctx, "sh", "-c", editor+` "$@"`, editor, path)
+}
+
+// editCommandLine opens the line being edited in $VISUAL or $EDITOR, leaving
+// raw mode while the editor runs. The line is replaced with the result and
+// redrawn below. It returns an error if ctx ends or raw mode can't be
+// restored, which ends editing.
+func (q *prompt) editCommandLine(ctx context.Context, e *editor) error {
+	e.cursor = len(e.line)
+	e.refresh()
+	fmt.Fprint(q.writer, "\r\n", disableBracketedPaste)
+	if q.cooked != nil {
+		term.Restore(q.fd, q.cooked)
+	}
+	text := string(e.line)
+	if e.mask != nil {
+		text = string(e.mask.format(e.line, false))
+	}
+	edited, err := q.openEditor(ctx, text)
+	if q.cooked != nil {
+		if _, err := term.MakeRaw(q.fd); err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(q.writer, enableBracketedPaste)
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		fmt.Fprint(q.writer, "\a")
+	default:
+		// Insert the result like typed text, so the line's filters apply.
+		runes, _ := cleanPaste(strings.TrimRight(edited, "\r\n"), PasteKeep, e.multiline)
+		if e.mask != nil {
+			// The line holds only what's typed into the mask's slots.
+			runes, _ = e.mask.scan(string(runes))
+		}
+		e.line, e.cursor = e.line[:0], 0
+		e.insert(runes...)
+	}
+
+	// Start over with the prompt on a fresh row.
+	fmt.Fprint(q.writer, e.prompt)
+	e.resetLayout()
+	return nil
+}
@@ -0,0 +1,99 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestEditorTemplate(t *testing.T) {
+	is := is.New(t)
+	is.Equal(editorTemplate("", "Message", nil), "\n# Message\n# Lines starting with '#' will be ignored.\n")
+	is.Equal(editorTemplate("hi", "Message", errors.New("too short")),
+		"hi\n\n# too short\n# Message\n# Lines starting with '#' will be ignored.\n")
+	is.Equal(stripComments(editorTemplate("hi\n  there", "Message", nil)), "hi\n  there")
+}
+
+func TestEditCommandLine(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor scripts need a POSIX shell")
+	}
+	is := is.New(t)
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", `sed -i.bak 's/world/there/'`)
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "hello world\x18\x05")
+	is.Equal(string(e.line), "hello there")
+	is.Equal(e.cursor, 11)
+
+	typeKeys(t, e, "\x1f")
+	is.Equal(string(e.line), "hello world")
+}
+
+func TestEditCommandLineFilters(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor scripts need a POSIX shell")
+	}
+	is := is.New(t)
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", `sed -i.bak 's/.*/abc12345/'`)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.allows = func(r rune) bool { return r >= '0' && r <= '9' }
+	e.maxLength, e.bell = 3, true
+
+	// The edited line is filtered like typed text.
+	typeKeys(t, e, "1\x18\x05")
+	is.Equal(string(e.line), "123")
+	is.Equal(e.cursor, 3)
+	is.True(strings.Contains(writer.String(), "\a"))
+}
+
+func TestEditCommandLineMask(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor scripts need a POSIX shell")
+	}
+	is := is.New(t)
+	t.Setenv("TMPDIR", t.TempDir())
+	// The editor sees the formatted value.
+	t.Setenv("VISUAL", `sed -i.bak 's/^(555) 1$/(555) 123-4567/'`)
+	e := newEditor(io.Discard, -1, "? ")
+	e.mask = parseMask("(999) 999-9999")
+
+	typeKeys(t, e, "5551\x18\x05")
+	is.Equal(string(e.line), "5551234567")
+	is.Equal(e.cursor, 10)
+	line, _ := e.shown()
+	is.Equal(string(line), "(555) 123-4567")
+}
+
+func TestEditCommandLineCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor scripts need a POSIX shell")
+	}
+	is := is.New(t)
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", "exec sleep 10 #")
+	q := newPrompt(WithWriter(io.Discard))
+	e := newEditor(io.Discard, -1, "? ")
+	e.line = []rune("hello")
+
+	// Cancelling the prompt stops the editor.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	done, _, err := q.runAction(ctx, e, ActionEditCommandLine)
+	is.True(done)
+	is.True(errors.Is(err, context.Canceled))
+	is.Equal(string(e.line), "hello")
+}
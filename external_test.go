@@ -0,0 +1,109 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func setEditor(t *testing.T, editor string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("editor scripts need a POSIX shell")
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+}
+
+func TestEditor(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	setEditor(t, `sed -i.bak 's/^draft$/final/'`)
+
+	text, err := prompt.Editor(ctx, "Commit message",
+		prompt.WithWriter(io.Discard),
+		prompt.WithTemplate("draft\n\nbody"),
+	)
+	is.NoErr(err)
+	is.Equal(text, "final\n\nbody")
+}
+
+func TestEditorStripsComments(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	setEditor(t, `printf '# heading\nhello\n#more\n\n' >`)
+
+	text, err := prompt.Editor(ctx, "Message", prompt.WithWriter(io.Discard))
+	is.NoErr(err)
+	is.Equal(text, "hello")
+}
+
+func TestEditorReopen(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	setEditor(t, `sed -i.bak '1s/^x*$/&x/'`)
+	writer := new(bytes.Buffer)
+	tooShort := errors.New("too short")
+
+	text, err := prompt.Editor(ctx, "Message",
+		prompt.WithReader(bytes.NewBufferString("y\n")),
+		prompt.WithWriter(writer),
+		prompt.WithCheck(func(s string) error {
+			if len(s) < 2 {
+				return tooShort
+			}
+			return nil
+		}),
+	)
+	is.NoErr(err)
+	is.Equal(text, "xx")
+	is.Equal(writer.String(), "too short\nEdit again? (y/n) ")
+}
+
+func TestEditorTransformError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	setEditor(t, `sed -i.bak '1s/^x*$/&x/'`)
+	writer := new(bytes.Buffer)
+
+	text, err := prompt.Editor(ctx, "Message",
+		prompt.WithReader(bytes.NewBufferString("y\n")),
+		prompt.WithWriter(writer),
+		prompt.WithTransform(func(s string) (string, error) {
+			if len(s) < 2 {
+				return "", errors.New("too short")
+			}
+			return strings.ToUpper(s), nil
+		}),
+	)
+	is.NoErr(err)
+	is.Equal(text, "XX")
+	is.Equal(writer.String(), "too short\nEdit again? (y/n) ")
+}
+
+func TestEditorGiveUp(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	setEditor(t, "true")
+
+	_, err := prompt.Editor(ctx, "Message",
+		prompt.WithReader(bytes.NewBufferString("n\n")),
+		prompt.WithWriter(io.Discard),
+	)
+	is.True(errors.Is(err, prompt.ErrRequired))
+
+	text, err := prompt.Editor(ctx, "Message",
+		prompt.WithWriter(io.Discard),
+		prompt.WithDefault("nothing"),
+	)
+	is.NoErr(err)
+	is.Equal(text, "nothing")
+}
@@ -0,0 +1,79 @@
+package prompt
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// WithAllowedRunes only accepts runes that allowed returns true for. In a
+// terminal, other runes are dropped as they're typed or pasted. Other input
+// fails with an error like a check.
+func WithAllowedRunes(allowed func(r rune) bool) Option {
+	return func(q *prompt) {
+		q.allowedRunes = allowed
+	}
+}
+
+// WithMaxLength limits the input to n characters. In a terminal, typing
+// stops at the limit. Longer input from elsewhere fails like a check.
+func WithMaxLength(n int) Option {
+	return func(q *prompt) {
+		q.maxLength = n
+	}
+}
+
+// WithBell rings the terminal bell when typed input is rejected.
+func WithBell(bell bool) Option {
+	return func(q *prompt) {
+		q.bell = bell
+	}
+}
+
+// checkRunes applies the allowed runes and maximum length to input.
+func (q *prompt) checkRunes(input string) error {
+	if q.allowedRunes != nil {
+		for _, r := range input {
+			if !q.allows(r) {
+				return fmt.Errorf("%q is not allowed", r)
+			}
+		}
+	}
+	if q.maxLength > 0 && utf8.RuneCountInString(input) > q.maxLength {
+		return fmt.Errorf("must be at most %d characters", q.maxLength)
+	}
+	return nil
+}
+
+// allows reports whether r can be entered. Newlines are always allowed in
+// multi-line prompts.
+func (q *prompt) allows(r rune) bool {
+	return q.allowedRunes == nil || (r == '\n' && q.multiline) || q.allowedRunes(r)
+}
+
+// filter returns the runes that may be inserted into the line and whether
+// any were dropped.
+func (e *editor) filter(runes []rune) ([]rune, bool) {
+	if e.allows == nil && e.maxLength <= 0 && e.mask == nil {
+		return runes, false
+	}
+	kept := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if e.allows != nil && !e.allows(r) {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	if e.mask != nil {
+		kept = e.fitMask(kept)
+	}
+	if e.maxLength > 0 {
+		room := e.maxLength - len(e.line)
+		if room < 0 {
+			room = 0
+		}
+		if len(kept) > room {
+			kept = kept[:room]
+		}
+	}
+	return kept, len(kept) < len(runes)
+}
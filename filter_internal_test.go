@@ -0,0 +1,41 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/matryer/is"
+)
+
+func TestEditorFilter(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	q := newPrompt(WithAllowedRunes(unicode.IsDigit))
+	e := newEditor(writer, -1, "? ")
+	e.allows, e.maxLength, e.bell = q.allows, 4, true
+
+	typeKeys(t, e, "1a2")
+	is.Equal(string(e.line), "12")
+	is.Equal(strings.Count(writer.String(), "\a"), 1)
+
+	typeKeys(t, e, "\x1b[200~3 4 5\x1b[201~")
+	is.Equal(string(e.line), "1234")
+	is.Equal(strings.Count(writer.String(), "\a"), 2)
+
+	// The limit counts the whole line, wherever the cursor is.
+	typeKeys(t, e, "\x019")
+	is.Equal(string(e.line), "1234")
+}
+
+func TestEditorFilterMultiline(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithAllowedRunes(unicode.IsLetter))
+	q.multiline = true
+	e := newTextEditor("? ", 80)
+	e.allows = q.allows
+
+	typeKeys(t, e, "ab\r1c", withTextKeymap())
+	is.Equal(string(e.line), "ab\nc")
+}
@@ -0,0 +1,27 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"unicode"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestAskAllowedRunes(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+
+	code, err := prompt.Ask(ctx, "Code?",
+		prompt.WithReader(bytes.NewBufferString("12a4\n12345\n1234\n")),
+		prompt.WithWriter(writer),
+		prompt.WithAllowedRunes(unicode.IsDigit),
+		prompt.WithMaxLength(4),
+	)
+	is.NoErr(err)
+	is.Equal(code, "1234")
+	is.Equal(writer.String(), "Code? 'a' is not allowed\nCode? must be at most 4 characters\nCode? ")
+}
@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/matryer/is v1.4.1
 	github.com/matthewmueller/diff v0.0.3
+	golang.org/x/sys v0.27.0
 	golang.org/x/term v0.26.0
 )
 
@@ -15,7 +16,6 @@ require (
 	github.com/sergi/go-diff v1.0.0 // indirect
 	github.com/shurcooL/go-goon v0.0.0-20170922171312-37c2f522c041 // indirect
 	golang.org/x/mod v0.5.1 // indirect
-	golang.org/x/sys v0.27.0 // indirect
 	golang.org/x/tools v0.1.8-0.20211102182255-bb4add04ddef // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	mvdan.cc/gofumpt v0.2.0 // indirect
@@ -0,0 +1,207 @@
+package prompt
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeProperty is a simplified Grapheme_Cluster_Break property from
+// UAX #29, covering the classes needed to segment terminal input.
+type graphemeProperty int
+
+const (
+	gpOther graphemeProperty = iota
+	gpCR
+	gpLF
+	gpControl
+	gpExtend
+	gpZWJ
+	gpRegionalIndicator
+	gpSpacingMark
+	gpL
+	gpV
+	gpT
+	gpLV
+	gpLVT
+)
+
+func graphemePropertyOf(r rune) graphemeProperty {
+	switch {
+	case r == '\r':
+		return gpCR
+	case r == '\n':
+		return gpLF
+	case r == 0x200d:
+		return gpZWJ
+	case r == 0x200c, r >= 0xfe00 && r <= 0xfe0f, r >= 0x1f3fb && r <= 0x1f3ff,
+		r >= 0xe0020 && r <= 0xe007f, unicode.In(r, unicode.Mn, unicode.Me):
+		return gpExtend
+	case unicode.IsControl(r), unicode.In(r, unicode.Zl, unicode.Zp):
+		return gpControl
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return gpRegionalIndicator
+	case unicode.Is(unicode.Mc, r):
+		return gpSpacingMark
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return gpL
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return gpV
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return gpT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return gpLV
+		}
+		return gpLVT
+	}
+	return gpOther
+}
+
+// isExtendedPictographic approximates the Extended_Pictographic property
+// used to keep emoji ZWJ sequences together.
+func isExtendedPictographic(r rune) bool {
+	switch {
+	case r == 0xa9, r == 0xae, r == 0x203c, r == 0x2049, r == 0x2122, r == 0x2139,
+		r >= 0x2194 && r <= 0x21aa, r >= 0x2300 && r <= 0x23ff,
+		r >= 0x25aa && r <= 0x27bf, r >= 0x2b00 && r <= 0x2bff,
+		r == 0x3030, r == 0x303d, r == 0x3297, r == 0x3299,
+		r >= 0x1f000 && r <= 0x1f1e5, r >= 0x1f200 && r <= 0x1faff,
+		r >= 0x1fc00 && r <= 0x1fffd:
+		return true
+	}
+	return false
+}
+
+// graphemeBreak reports whether there is a cluster boundary between
+// line[i-1] and line[i].
+func graphemeBreak(line []rune, i int) bool {
+	if i <= 0 || i >= len(line) {
+		return true
+	}
+	prev := graphemePropertyOf(line[i-1])
+	next := graphemePropertyOf(line[i])
+	switch {
+	case prev == gpCR && next == gpLF: // GB3
+		return false
+	case prev == gpCR, prev == gpLF, prev == gpControl: // GB4
+		return true
+	case next == gpCR, next == gpLF, next == gpControl: // GB5
+		return true
+	case prev == gpL && (next == gpL || next == gpV || next == gpLV || next == gpLVT): // GB6
+		return false
+	case (prev == gpLV || prev == gpV) && (next == gpV || next == gpT): // GB7
+		return false
+	case (prev == gpLVT || prev == gpT) && next == gpT: // GB8
+		return false
+	case next == gpExtend, next == gpZWJ, next == gpSpacingMark: // GB9, GB9a
+		return false
+	case prev == gpZWJ && isExtendedPictographic(line[i]): // GB11
+		j := i - 2
+		for j >= 0 && graphemePropertyOf(line[j]) == gpExtend {
+			j--
+		}
+		return j < 0 || !isExtendedPictographic(line[j])
+	case prev == gpRegionalIndicator && next == gpRegionalIndicator: // GB12, GB13
+		count := 0
+		for j := i - 1; j >= 0 && graphemePropertyOf(line[j]) == gpRegionalIndicator; j-- {
+			count++
+		}
+		return count%2 == 0
+	}
+	return true // GB999
+}
+
+// nextGrapheme returns the index of the cluster boundary after cursor.
+func nextGrapheme(line []rune, cursor int) int {
+	if cursor >= len(line) {
+		return len(line)
+	}
+	cursor++
+	for cursor < len(line) && !graphemeBreak(line, cursor) {
+		cursor++
+	}
+	return cursor
+}
+
+// prevGrapheme returns the index of the cluster boundary before cursor.
+func prevGrapheme(line []rune, cursor int) int {
+	if cursor <= 0 {
+		return 0
+	}
+	cursor--
+	for cursor > 0 && !graphemeBreak(line, cursor) {
+		cursor--
+	}
+	return cursor
+}
+
+// clusterWidth returns the number of terminal columns taken by a cluster.
+func clusterWidth(cluster []rune) int {
+	if len(cluster) == 0 {
+		return 0
+	}
+	r := cluster[0]
+	switch graphemePropertyOf(r) {
+	case gpExtend, gpZWJ:
+		return 0
+	case gpControl, gpCR, gpLF:
+		return utf8.RuneCountInString(displayString(cluster))
+	}
+	if isWideRune(r) {
+		return 2
+	}
+	for _, r := range cluster[1:] {
+		if r == 0xfe0f && isExtendedPictographic(cluster[0]) {
+			return 2
+		}
+	}
+	return 1
+}
+
+// isWideRune reports whether r is East Asian Wide, Fullwidth or an emoji
+// with default emoji presentation.
+func isWideRune(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115f, r >= 0x2e80 && r <= 0x303e,
+		r >= 0x3041 && r <= 0x33ff, r >= 0x3400 && r <= 0x4dbf,
+		r >= 0x4e00 && r <= 0x9fff, r >= 0xa000 && r <= 0xa4cf,
+		r >= 0xa960 && r <= 0xa97f, r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff, r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60, r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f1e6 && r <= 0x1f1ff, r >= 0x1f300 && r <= 0x1f64f,
+		r >= 0x1f680 && r <= 0x1f6ff, r >= 0x1f900 && r <= 0x1f9ff,
+		r >= 0x1fa70 && r <= 0x1faff, r >= 0x20000 && r <= 0x3fffd:
+		return true
+	}
+	return false
+}
+
+// displayString returns line as it should be printed, showing control
+// characters in caret notation so they can't affect the terminal.
+func displayString(line []rune) string {
+	var b strings.Builder
+	for _, r := range line {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			b.WriteByte('^')
+			b.WriteRune(r ^ 0x40)
+		case unicode.IsControl(r):
+			b.WriteRune(utf8.RuneError)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// stringWidth returns the number of terminal columns needed to display line.
+func stringWidth(line []rune) int {
+	width := 0
+	for i := 0; i < len(line); {
+		next := nextGrapheme(line, i)
+		width += clusterWidth(line[i:next])
+		i = next
+	}
+	return width
+}
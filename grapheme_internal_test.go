@@ -0,0 +1,95 @@
+package prompt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGraphemeCombiningMark(t *testing.T) {
+	is := is.New(t)
+	line := []rune("cafe\u0301!")
+
+	is.Equal(prevGrapheme(line, 5), 3)
+	is.Equal(nextGrapheme(line, 3), 5)
+	is.Equal(stringWidth(line), 5)
+}
+
+func TestGraphemeFlag(t *testing.T) {
+	is := is.New(t)
+	line := []rune("a\U0001F1FA\U0001F1F8\U0001F1EB\U0001F1F7")
+
+	is.Equal(nextGrapheme(line, 1), 3)
+	is.Equal(nextGrapheme(line, 3), 5)
+	is.Equal(prevGrapheme(line, 5), 3)
+	is.Equal(stringWidth(line), 5)
+}
+
+func TestGraphemeZWJSequence(t *testing.T) {
+	is := is.New(t)
+	// Family: man, woman, girl joined with zero width joiners.
+	line := []rune("\U0001F468‍\U0001F469‍\U0001F467x")
+
+	is.Equal(nextGrapheme(line, 0), 5)
+	is.Equal(prevGrapheme(line, 5), 0)
+	is.Equal(stringWidth(line), 3)
+}
+
+func TestGraphemeHangulJamo(t *testing.T) {
+	is := is.New(t)
+	line := []rune("각")
+
+	is.Equal(nextGrapheme(line, 0), 3)
+	is.Equal(stringWidth(line), 2)
+}
+
+func TestBackwardDeleteGrapheme(t *testing.T) {
+	is := is.New(t)
+	line := []rune("ok\U0001F1FA\U0001F1F8")
+
+	line, cursor := backwardDeleteGrapheme(line, len(line))
+	is.Equal(string(line), "ok")
+	is.Equal(cursor, 2)
+}
+
+func TestDeleteGrapheme(t *testing.T) {
+	is := is.New(t)
+	line := []rune("e\u0301a")
+
+	line = deleteGrapheme(line, 0)
+	is.Equal(string(line), "a")
+}
+
+func TestApplyEscapeSequenceArrowsGrapheme(t *testing.T) {
+	is := is.New(t)
+	line := []rune("e\u0301e\u0301")
+
+	_, cursor := applyEscapeSequence("[C", line, 0)
+	is.Equal(cursor, 2)
+
+	_, cursor = applyEscapeSequence("[D", line, len(line))
+	is.Equal(cursor, 2)
+
+	line, cursor = applyEscapeSequence("[3~", line, 0)
+	is.Equal(string(line), "e\u0301")
+	is.Equal(cursor, 0)
+}
+
+func TestBackwardKillWordGrapheme(t *testing.T) {
+	is := is.New(t)
+	line := []rune("café nai\u0308ve")
+
+	line, cursor := backwardKillWord(line, len(line))
+	is.Equal(string(line), "café ")
+	is.Equal(cursor, 5)
+}
+
+func TestRedrawTerminalLineWideRunes(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+
+	redrawTerminalLine(writer, []rune("日本"), nil, 2, 2, 4, 0, 80)
+
+	is.Equal(writer.String(), "\r日本\r\x1b[4C")
+}
@@ -0,0 +1,75 @@
+package prompt
+
+import (
+	"strings"
+	"sync"
+)
+
+const defaultHistorySize = 500
+
+// History records submitted input so it can be recalled while editing.
+// Share a history between prompts with WithHistory.
+type History struct {
+	mu      sync.Mutex
+	size    int
+	entries []string // oldest first
+}
+
+// NewHistory creates a history that remembers up to size entries.
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+	return &History{size: size}
+}
+
+// WithHistory records submitted input in the history and makes it
+// available to history commands like Alt+.
+func WithHistory(history *History) Option {
+	return func(q *prompt) {
+		if history == nil {
+			return
+		}
+		q.history = history
+	}
+}
+
+// Add appends an entry to the history. Empty entries and repeats of the
+// most recent entry are skipped.
+func (h *History) Add(entry string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+}
+
+// Entries returns a copy of the history, oldest first.
+func (h *History) Entries() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.entries...)
+}
+
+// at returns the entry n steps back from the most recent one.
+func (h *History) at(n int) (string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n < 0 || n >= len(h.entries) {
+		return "", false
+	}
+	return h.entries[len(h.entries)-1-n], true
+}
+
+// lastArg returns the last whitespace-separated word of an entry.
+func lastArg(entry string) string {
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
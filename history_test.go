@@ -0,0 +1,35 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestAskHistory(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	history := prompt.NewHistory(2)
+	reader := prompt.WithReader(bytes.NewBufferString("one\ntwo\ntwo\n\nthree\n"))
+
+	for i := 0; i < 4; i++ {
+		_, err := prompt.Ask(ctx, "Next?",
+			reader,
+			prompt.WithWriter(io.Discard),
+			prompt.WithOptional(true),
+			prompt.WithHistory(history),
+		)
+		is.NoErr(err)
+	}
+	_, err := prompt.Ask(ctx, "Next?",
+		reader,
+		prompt.WithWriter(io.Discard),
+		prompt.WithHistory(history),
+	)
+	is.NoErr(err)
+	is.Equal(history.Entries(), []string{"two", "three"})
+}
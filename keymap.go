@@ -0,0 +1,466 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Action is a named line editor command. Names follow GNU Readline where
+// there is an equivalent.
+type Action string
+
+// Editor actions that can be bound in a Keymap.
+const (
+	ActionAcceptLine         Action = "accept-line"
+	ActionInterrupt          Action = "interrupt"
+	ActionBeginningOfLine    Action = "beginning-of-line"
+	ActionEndOfLine          Action = "end-of-line"
+	ActionBackwardChar       Action = "backward-char"
+	ActionForwardChar        Action = "forward-char"
+	ActionBackwardWord       Action = "backward-word"
+	ActionForwardWord        Action = "forward-word"
+	ActionDeleteChar         Action = "delete-char"
+	ActionDeleteCharOrEOF    Action = "delete-char-or-eof"
+	ActionBackwardDeleteChar Action = "backward-delete-char"
+	ActionKillLine           Action = "kill-line"
+	ActionUnixLineDiscard    Action = "unix-line-discard"
+	ActionUnixWordRubout     Action = "unix-word-rubout"
+	ActionBackwardKillWord   Action = "backward-kill-word"
+	ActionYank               Action = "yank"
+	ActionYankPop            Action = "yank-pop"
+	ActionUndo               Action = "undo"
+	ActionRedo               Action = "redo"
+	ActionBracketedPaste     Action = "bracketed-paste-begin"
+	ActionKillWord           Action = "kill-word"
+	ActionTransposeChars     Action = "transpose-chars"
+	ActionTransposeWords     Action = "transpose-words"
+	ActionUpcaseWord         Action = "upcase-word"
+	ActionDowncaseWord       Action = "downcase-word"
+	ActionCapitalizeWord     Action = "capitalize-word"
+	ActionYankLastArg        Action = "yank-last-arg"
+	ActionQuotedInsert       Action = "quoted-insert"
+	ActionInsertNewline      Action = "insert-newline"
+	ActionPreviousLine       Action = "previous-line"
+	ActionNextLine           Action = "next-line"
+	ActionEditCommandLine    Action = "edit-command-line"
+	ActionPreviousDay        Action = "previous-day"
+	ActionNextDay            Action = "next-day"
+	ActionPreviousWeek       Action = "previous-week"
+	ActionNextWeek           Action = "next-week"
+	ActionPreviousMonth      Action = "previous-month"
+	ActionNextMonth          Action = "next-month"
+	ActionIncrement          Action = "increment"
+	ActionDecrement          Action = "decrement"
+	ActionIncrementPage      Action = "increment-page"
+	ActionDecrementPage      Action = "decrement-page"
+)
+
+// editorActions are the actions that only change the line and cursor.
+var editorActions = map[Action]func(e *editor){
+	ActionBeginningOfLine: func(e *editor) { e.cursor = lineStart(e.line, e.cursor) },
+	ActionEndOfLine:       func(e *editor) { e.cursor = lineEnd(e.line, e.cursor) },
+	ActionBackwardChar:    func(e *editor) { e.cursor = prevGrapheme(e.line, e.cursor) },
+	ActionForwardChar: func(e *editor) {
+		if !e.acceptSuggestion(false) {
+			e.cursor = nextGrapheme(e.line, e.cursor)
+		}
+	},
+	ActionBackwardWord: func(e *editor) { e.cursor = moveCursorWordLeft(e.line, e.cursor) },
+	ActionForwardWord: func(e *editor) {
+		if !e.acceptSuggestion(true) {
+			e.cursor = moveCursorWordRight(e.line, e.cursor)
+		}
+	},
+	ActionDeleteChar: func(e *editor) { e.line = deleteGrapheme(e.line, e.cursor) },
+	ActionBackwardDeleteChar: func(e *editor) {
+		e.line, e.cursor = backwardDeleteGrapheme(e.line, e.cursor)
+	},
+	ActionKillLine: func(e *editor) {
+		end := lineEnd(e.line, e.cursor)
+		if end == e.cursor && end < len(e.line) {
+			end++ // Join the next line.
+		}
+		e.kill(e.cursor, end)
+	},
+	ActionUnixLineDiscard: func(e *editor) { e.kill(lineStart(e.line, e.cursor), e.cursor) },
+	ActionUnixWordRubout: func(e *editor) {
+		e.kill(moveCursorWordLeft(e.line, e.cursor), e.cursor)
+	},
+	ActionBackwardKillWord: func(e *editor) {
+		e.kill(moveCursorWordLeft(e.line, e.cursor), e.cursor)
+	},
+	ActionKillWord: func(e *editor) {
+		e.kill(e.cursor, moveCursorWordRight(e.line, e.cursor))
+	},
+	ActionTransposeChars: func(e *editor) {
+		e.line, e.cursor = transposeChars(e.line, e.cursor)
+	},
+	ActionTransposeWords: func(e *editor) {
+		e.line, e.cursor = transposeWords(e.line, e.cursor)
+	},
+	ActionUpcaseWord: func(e *editor) {
+		e.cursor = mapWord(e.line, e.cursor, unicode.ToUpper)
+	},
+	ActionDowncaseWord: func(e *editor) {
+		e.cursor = mapWord(e.line, e.cursor, unicode.ToLower)
+	},
+	ActionCapitalizeWord: func(e *editor) {
+		e.cursor = capitalizeWord(e.line, e.cursor)
+	},
+	ActionYank:        func(e *editor) { e.yank() },
+	ActionYankPop:     func(e *editor) { e.yankPop() },
+	ActionYankLastArg: func(e *editor) { e.yankLastArg() },
+	ActionUndo:        func(e *editor) { e.undo() },
+	ActionRedo:        func(e *editor) { e.redo() },
+	ActionInsertNewline: func(e *editor) {
+		if e.multiline {
+			e.insert('\n')
+		}
+	},
+	ActionPreviousLine:  func(e *editor) { e.cursor = moveLine(e.line, e.cursor, -1) },
+	ActionNextLine:      func(e *editor) { e.cursor = moveLine(e.line, e.cursor, 1) },
+	ActionPreviousDay:   func(e *editor) { e.moveDate(0, -1) },
+	ActionNextDay:       func(e *editor) { e.moveDate(0, 1) },
+	ActionPreviousWeek:  func(e *editor) { e.moveDate(0, -7) },
+	ActionNextWeek:      func(e *editor) { e.moveDate(0, 7) },
+	ActionPreviousMonth: func(e *editor) { e.moveDate(-1, 0) },
+	ActionNextMonth:     func(e *editor) { e.moveDate(1, 0) },
+	ActionIncrement:     func(e *editor) { e.stepNumber(1, false) },
+	ActionDecrement:     func(e *editor) { e.stepNumber(-1, false) },
+	ActionIncrementPage: func(e *editor) { e.stepNumber(1, true) },
+	ActionDecrementPage: func(e *editor) { e.stepNumber(-1, true) },
+}
+
+// isAction reports whether the editor knows how to run action.
+func isAction(action Action) bool {
+	switch action {
+	case ActionAcceptLine, ActionInterrupt, ActionDeleteCharOrEOF, ActionBracketedPaste, ActionQuotedInsert,
+		ActionEditCommandLine:
+		return true
+	}
+	_, ok := editorActions[action]
+	return ok
+}
+
+// Keymap maps key sequences to editor actions. Key sequences are the raw
+// bytes sent by the terminal, so Ctrl+A is "\x01" and the left arrow is
+// "\x1b[D". A sequence can be a chord of several keys, like "\x18\x15" for
+// Ctrl+X Ctrl+U.
+type Keymap struct {
+	bindings map[string]Action
+}
+
+// defaultKeymap is shared by prompts that don't set their own keymap.
+var defaultKeymap = newEmacsKeymap()
+
+// DefaultKeymap returns a copy of the default Emacs keymap, ready to be
+// customized and passed to WithKeymap.
+func DefaultKeymap() *Keymap {
+	return defaultKeymap.clone()
+}
+
+// WithKeymap sets the key bindings used by the line editor.
+func WithKeymap(keymap *Keymap) Option {
+	return func(q *prompt) {
+		if keymap == nil {
+			return
+		}
+		q.keymap = keymap
+	}
+}
+
+func newEmacsKeymap() *Keymap {
+	k := &Keymap{bindings: map[string]Action{}}
+	k.bindAll(ActionAcceptLine, "\r", "\n")
+	k.bindAll(ActionInterrupt, "\x03")
+	k.bindAll(ActionBeginningOfLine, "\x01", "\x1b[H", "\x1b[1~", "\x1b[7~", "\x1bOH")
+	k.bindAll(ActionEndOfLine, "\x05", "\x1b[F", "\x1b[4~", "\x1b[8~", "\x1bOF")
+	k.bindAll(ActionBackwardChar, "\x02", "\x1b[D", "\x1bOD")
+	k.bindAll(ActionForwardChar, "\x06", "\x1b[C", "\x1bOC")
+	k.bindAll(ActionBackwardWord, "\x1bb", "\x1bB", "\x1b[1;5D", "\x1b[5D")
+	k.bindAll(ActionForwardWord, "\x1bf", "\x1bF", "\x1b[1;5C", "\x1b[5C")
+	k.bindAll(ActionDeleteChar, "\x1b[3~")
+	k.bindAll(ActionDeleteCharOrEOF, "\x04")
+	k.bindAll(ActionBackwardDeleteChar, "\x08", "\x7f")
+	k.bindAll(ActionKillLine, "\x0b")
+	k.bindAll(ActionUnixLineDiscard, "\x15")
+	k.bindAll(ActionUnixWordRubout, "\x17")
+	k.bindAll(ActionBackwardKillWord, "\x1b\x7f", "\x1b\x08", "\x1b[3;3~", "\x1b[8;3u", "\x1b[127;3u")
+	k.bindAll(ActionYank, "\x19")
+	k.bindAll(ActionYankPop, "\x1by", "\x1bY")
+	k.bindAll(ActionUndo, "\x1f", "\x18\x15")
+	k.bindAll(ActionRedo, "\x18\x12")
+	k.bindAll(ActionBracketedPaste, "\x1b"+pasteStart)
+	k.bindAll(ActionKillWord, "\x1bd", "\x1bD")
+	k.bindAll(ActionTransposeChars, "\x14")
+	k.bindAll(ActionTransposeWords, "\x1bt", "\x1bT")
+	k.bindAll(ActionUpcaseWord, "\x1bu", "\x1bU")
+	k.bindAll(ActionDowncaseWord, "\x1bl", "\x1bL")
+	k.bindAll(ActionCapitalizeWord, "\x1bc", "\x1bC")
+	k.bindAll(ActionYankLastArg, "\x1b.", "\x1b_")
+	k.bindAll(ActionQuotedInsert, "\x16")
+	k.bindAll(ActionEditCommandLine, "\x18\x05")
+	return k
+}
+
+func (k *Keymap) clone() *Keymap {
+	bindings := make(map[string]Action, len(k.bindings))
+	for key, action := range k.bindings {
+		bindings[key] = action
+	}
+	return &Keymap{bindings: bindings}
+}
+
+// Bind binds a key sequence to an action, replacing any existing binding.
+func (k *Keymap) Bind(keys string, action Action) {
+	if k.bindings == nil {
+		k.bindings = map[string]Action{}
+	}
+	k.bindings[keys] = action
+}
+
+// bindAll binds each of keys to action.
+func (k *Keymap) bindAll(action Action, keys ...string) {
+	for _, key := range keys {
+		k.Bind(key, action)
+	}
+}
+
+// Unbind removes the binding for a key sequence.
+func (k *Keymap) Unbind(keys string) {
+	delete(k.bindings, keys)
+}
+
+// Lookup returns the action bound to a key sequence.
+func (k *Keymap) Lookup(keys string) (Action, bool) {
+	action, ok := k.bindings[keys]
+	return action, ok
+}
+
+// isPrefix reports whether keys is the start of a longer key sequence.
+func (k *Keymap) isPrefix(keys string) bool {
+	for bound := range k.bindings {
+		if len(bound) > len(keys) && strings.HasPrefix(bound, keys) {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads bindings from r in inputrc format, overriding existing
+// bindings. Both key names and quoted key sequences are supported:
+//
+//	Control-w: backward-kill-word
+//	"\C-xu": undo
+//	"\e[1;5D": backward-word
+//
+// Variable assignments and conditional directives are ignored.
+func (k *Keymap) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == '$' || strings.HasPrefix(line, "set ") {
+			continue
+		}
+		keys, action, err := parseInputrcBinding(line)
+		if err != nil {
+			return fmt.Errorf("prompt: keymap line %d: %w", lineno, err)
+		}
+		k.Bind(keys, action)
+	}
+	return scanner.Err()
+}
+
+// parseInputrcBinding parses a single "keys: action" line.
+func parseInputrcBinding(line string) (string, Action, error) {
+	var keys, rest string
+	if line[0] == '"' {
+		end := closingQuote(line)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated key sequence %s", line)
+		}
+		seq, err := parseKeySequence(line[1:end])
+		if err != nil {
+			return "", "", err
+		}
+		keys, rest = seq, line[end+1:]
+	} else {
+		name, after, ok := strings.Cut(line, ":")
+		if !ok {
+			return "", "", fmt.Errorf("missing ':' in %q", line)
+		}
+		seq, err := parseKeyName(strings.TrimSpace(name))
+		if err != nil {
+			return "", "", err
+		}
+		keys, rest = seq, ":"+after
+	}
+	rest = strings.TrimSpace(rest)
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", fmt.Errorf("missing ':' in %q", line)
+	}
+	value := strings.TrimSpace(rest[1:])
+	if strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'") {
+		return "", "", fmt.Errorf("macros are not supported: %s", value)
+	}
+	action := Action(value)
+	if !isAction(action) {
+		return "", "", fmt.Errorf("unknown action %q", value)
+	}
+	return keys, action, nil
+}
+
+// closingQuote returns the index of the quote closing the key sequence that
+// starts at line[0].
+func closingQuote(line string) int {
+	for i := 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseKeySequence parses the body of a quoted inputrc key sequence.
+func parseKeySequence(s string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			out.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", fmt.Errorf("trailing backslash in %q", s)
+		}
+		switch c := s[i]; {
+		case (c == 'C' || c == 'M') && i+2 < len(s) && s[i+1] == '-':
+			// \C-x and \M-x, which may be combined as \M-\C-x.
+			target := s[i+2]
+			next := i + 2
+			if target == '\\' && c == 'M' && strings.HasPrefix(s[i+2:], `\C-`) && i+5 < len(s) {
+				target = controlKey(s[i+5])
+				next = i + 5
+			} else if c == 'C' {
+				target = controlKey(target)
+			}
+			if c == 'M' {
+				out.WriteByte(0x1b)
+			}
+			out.WriteByte(target)
+			i = next
+		case c == 'e':
+			out.WriteByte(0x1b)
+		case c == 'a':
+			out.WriteByte('\a')
+		case c == 'b':
+			out.WriteByte('\b')
+		case c == 'd':
+			out.WriteByte(0x7f)
+		case c == 'f':
+			out.WriteByte('\f')
+		case c == 'n':
+			out.WriteByte('\n')
+		case c == 'r':
+			out.WriteByte('\r')
+		case c == 't':
+			out.WriteByte('\t')
+		case c == 'v':
+			out.WriteByte('\v')
+		case c >= '0' && c <= '7':
+			end := i + 1
+			for end < len(s) && end < i+3 && s[end] >= '0' && s[end] <= '7' {
+				end++
+			}
+			n, err := strconv.ParseUint(s[i:end], 8, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid octal escape in %q", s)
+			}
+			out.WriteByte(byte(n))
+			i = end - 1
+		case c == 'x':
+			end := i + 1
+			for end < len(s) && end < i+3 && isHexDigit(s[end]) {
+				end++
+			}
+			n, err := strconv.ParseUint(s[i+1:end], 16, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid hex escape in %q", s)
+			}
+			out.WriteByte(byte(n))
+			i = end - 1
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), nil
+}
+
+// parseKeyName parses an unquoted inputrc key name like Control-a or
+// Meta-Rubout.
+func parseKeyName(name string) (string, error) {
+	prefix := ""
+	for {
+		lower := strings.ToLower(name)
+		switch {
+		case strings.HasPrefix(lower, "control-"), strings.HasPrefix(lower, "c-"):
+			_, name, _ = strings.Cut(name, "-")
+			key, err := parseKeyName(name)
+			if err != nil {
+				return "", err
+			}
+			if len(key) != 1 {
+				return "", fmt.Errorf("invalid control key %q", name)
+			}
+			return prefix + string(controlKey(key[0])), nil
+		case strings.HasPrefix(lower, "meta-"), strings.HasPrefix(lower, "m-"):
+			_, name, _ = strings.Cut(name, "-")
+			prefix += "\x1b"
+			continue
+		}
+		break
+	}
+	switch strings.ToLower(name) {
+	case "rubout", "del":
+		return prefix + "\x7f", nil
+	case "esc", "escape":
+		return prefix + "\x1b", nil
+	case "lfd", "newline":
+		return prefix + "\n", nil
+	case "ret", "return":
+		return prefix + "\r", nil
+	case "spc", "space":
+		return prefix + " ", nil
+	case "tab":
+		return prefix + "\t", nil
+	}
+	if utf8.RuneCountInString(name) != 1 {
+		return "", fmt.Errorf("unknown key name %q", name)
+	}
+	return prefix + name, nil
+}
+
+// controlKey returns the control character for c, so 'a' becomes Ctrl+A.
+func controlKey(c byte) byte {
+	if c == '?' {
+		return 0x7f
+	}
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return c & 0x1f
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,90 @@
+package prompt
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseKeySequence(t *testing.T) {
+	is := is.New(t)
+	tests := map[string]string{
+		`\C-x\C-e`: "\x18\x05",
+		`\M-b`:     "\x1bb",
+		`\e[1;5D`:  "\x1b[1;5D",
+		`\M-\C-h`:  "\x1b\x08",
+		`\C-?`:     "\x7f",
+		`\177`:     "\x7f",
+		`\x7f`:     "\x7f",
+		`\\\"`:     `\"`,
+	}
+	for input, expect := range tests {
+		seq, err := parseKeySequence(input)
+		is.NoErr(err)
+		is.Equal(seq, expect)
+	}
+}
+
+func TestParseKeyName(t *testing.T) {
+	is := is.New(t)
+	tests := map[string]string{
+		"Control-a":      "\x01",
+		"C-w":            "\x17",
+		"Meta-Rubout":    "\x1b\x7f",
+		"M-C-h":          "\x1b\x08",
+		"Return":         "\r",
+		"Meta-b":         "\x1bb",
+		"Meta-Control-u": "\x1b\x15",
+	}
+	for input, expect := range tests {
+		seq, err := parseKeyName(input)
+		is.NoErr(err)
+		is.Equal(seq, expect)
+	}
+}
+
+func TestKeymapChord(t *testing.T) {
+	is := is.New(t)
+	keymap := DefaultKeymap()
+	keymap.Bind("\x18\x01", ActionEndOfLine)
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "hello\x01\x18\x01!", WithKeymap(keymap))
+	is.Equal(string(e.line), "hello!")
+}
+
+func TestKeymapUnboundChordIsIgnored(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "ab\x18zc")
+	is.Equal(string(e.line), "abc")
+}
+
+func TestKeymapOverride(t *testing.T) {
+	is := is.New(t)
+	keymap := DefaultKeymap()
+	err := keymap.Load(strings.NewReader("Control-w: backward-char\n"))
+	is.NoErr(err)
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "ab\x17c", WithKeymap(keymap))
+	is.Equal(string(e.line), "acb")
+
+	// The default keymap is unchanged.
+	action, ok := DefaultKeymap().Lookup("\x17")
+	is.True(ok)
+	is.Equal(action, ActionUnixWordRubout)
+}
+
+func TestKeymapUnbind(t *testing.T) {
+	is := is.New(t)
+	keymap := DefaultKeymap()
+	keymap.Unbind("\x15")
+	e := newEditor(io.Discard, -1, "? ")
+
+	typeKeys(t, e, "abc\x15", WithKeymap(keymap))
+	is.Equal(string(e.line), "abc")
+}
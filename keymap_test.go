@@ -0,0 +1,51 @@
+package prompt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestKeymapLoad(t *testing.T) {
+	is := is.New(t)
+	keymap := prompt.DefaultKeymap()
+	err := keymap.Load(strings.NewReader(`
+# Comments and settings are skipped
+set editing-mode emacs
+$if mode=emacs
+"\C-x\C-u": redo
+Meta-Rubout: unix-word-rubout
+"\e[1;3D": backward-word
+$endif
+`))
+	is.NoErr(err)
+
+	action, ok := keymap.Lookup("\x18\x15")
+	is.True(ok)
+	is.Equal(action, prompt.ActionRedo)
+
+	action, ok = keymap.Lookup("\x1b\x7f")
+	is.True(ok)
+	is.Equal(action, prompt.ActionUnixWordRubout)
+
+	action, ok = keymap.Lookup("\x1b[1;3D")
+	is.True(ok)
+	is.Equal(action, prompt.ActionBackwardWord)
+}
+
+func TestKeymapLoadUnknownAction(t *testing.T) {
+	is := is.New(t)
+	keymap := prompt.DefaultKeymap()
+	err := keymap.Load(strings.NewReader("\"\\C-a\": beginning-of-line\n\"\\C-b\": self-destruct\n"))
+	is.True(err != nil)
+	is.Equal(err.Error(), `prompt: keymap line 2: unknown action "self-destruct"`)
+}
+
+func TestKeymapLoadMacro(t *testing.T) {
+	is := is.New(t)
+	keymap := prompt.DefaultKeymap()
+	err := keymap.Load(strings.NewReader(`"\C-t": "hello"`))
+	is.True(err != nil)
+}
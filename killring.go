@@ -0,0 +1,71 @@
+package prompt
+
+import "sync"
+
+const defaultKillRingSize = 10
+
+// KillRing holds text removed by kill commands so it can be yanked back
+// with Ctrl+Y and Alt+Y. Share a ring between prompts with WithKillRing.
+type KillRing struct {
+	mu      sync.Mutex
+	size    int
+	entries []string // oldest first
+}
+
+// NewKillRing creates a kill ring that remembers up to size kills.
+func NewKillRing(size int) *KillRing {
+	if size <= 0 {
+		size = defaultKillRingSize
+	}
+	return &KillRing{size: size}
+}
+
+// WithKillRing shares a kill ring between prompts. By default, each prompt
+// has its own ring.
+func WithKillRing(ring *KillRing) Option {
+	return func(q *prompt) {
+		if ring == nil {
+			return
+		}
+		q.killRing = ring
+	}
+}
+
+// push adds a new kill to the ring.
+func (k *KillRing) push(text string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.entries = append(k.entries, text)
+	if len(k.entries) > k.size {
+		k.entries = k.entries[len(k.entries)-k.size:]
+	}
+}
+
+// extend merges text into the most recent kill, in front of it for backward
+// kills and after it otherwise.
+func (k *KillRing) extend(text string, backward bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if len(k.entries) == 0 {
+		k.entries = append(k.entries, text)
+		return
+	}
+	last := len(k.entries) - 1
+	if backward {
+		k.entries[last] = text + k.entries[last]
+	} else {
+		k.entries[last] += text
+	}
+}
+
+// at returns the kill n steps back from the most recent one, wrapping
+// around the ring.
+func (k *KillRing) at(n int) (string, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if len(k.entries) == 0 {
+		return "", false
+	}
+	n %= len(k.entries)
+	return k.entries[len(k.entries)-1-n], true
+}
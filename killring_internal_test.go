@@ -0,0 +1,87 @@
+package prompt
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestKillRingConsecutiveKills(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.line, e.cursor = []rune("hello brave new world"), 15
+
+	// Ctrl+W twice, then Ctrl+K.
+	typeKeys(t, e, "\x17\x17\x0b")
+	is.Equal(string(e.line), "hello ")
+
+	text, ok := e.kills.at(0)
+	is.True(ok)
+	is.Equal(text, "brave new world")
+}
+
+func TestKillRingYank(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.line, e.cursor = []rune("hello world"), 11
+
+	// Ctrl+W, Ctrl+A, Ctrl+Y.
+	typeKeys(t, e, "\x17\x01\x19")
+	is.Equal(string(e.line), "worldhello ")
+	is.Equal(e.cursor, 5)
+}
+
+func TestKillRingYankPop(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.line, e.cursor = []rune("one two"), 7
+
+	// Kill "two", move left to separate the kills, kill "one", then yank
+	// and rotate back to "two".
+	typeKeys(t, e, "\x17\x02\x17\x19\x1by")
+	is.Equal(string(e.line), "two ")
+	is.Equal(e.cursor, 3)
+
+	// Alt+Y wraps around the ring.
+	typeKeys(t, e, "\x1by")
+	is.Equal(string(e.line), "one ")
+}
+
+func TestKillRingYankPopRequiresYank(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.line, e.cursor = []rune("abc"), 3
+
+	typeKeys(t, e, "\x17\x1by")
+	is.Equal(string(e.line), "")
+}
+
+func TestKillRingShared(t *testing.T) {
+	is := is.New(t)
+	ring := NewKillRing(2)
+	first := newEditor(io.Discard, -1, "? ")
+	first.line, first.cursor = []rune("secret"), 6
+	first.kills = ring
+	typeKeys(t, first, "\x15")
+
+	second := newEditor(io.Discard, -1, "? ")
+	second.kills = ring
+	typeKeys(t, second, "\x19")
+	is.Equal(string(second.line), "secret")
+}
+
+func TestKillRingSize(t *testing.T) {
+	is := is.New(t)
+	ring := NewKillRing(2)
+	ring.push("a")
+	ring.push("b")
+	ring.push("c")
+
+	text, _ := ring.at(0)
+	is.Equal(text, "c")
+	text, _ = ring.at(1)
+	is.Equal(text, "b")
+	text, _ = ring.at(2)
+	is.Equal(text, "c")
+}
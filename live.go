@@ -0,0 +1,208 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	statusStart = "\x1b[31m"
+	statusEnd   = "\x1b[39m"
+)
+
+// WithLiveCheck runs the checks while typing in a terminal, once the input
+// has been unchanged for delay. The current error is shown on a status line
+// below the input and Enter is refused until the input is valid.
+func WithLiveCheck(delay time.Duration) Option {
+	return func(q *prompt) {
+		q.liveCheck = true
+		q.liveDelay = delay
+	}
+}
+
+// liveCheck runs checks in the background as the line changes. Its fields
+// are guarded by the editor's mutex.
+type liveCheck struct {
+	q       *prompt
+	e       *editor
+	ctx     context.Context
+	delay   time.Duration
+	line    string // line the latest check is for
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	gen     int
+	done    bool // the latest check has finished
+	err     error
+	stopped bool
+}
+
+func (q *prompt) startLiveCheck(ctx context.Context, e *editor) *liveCheck {
+	l := &liveCheck{q: q, e: e, ctx: ctx, delay: q.liveDelay}
+	l.schedule()
+	return l
+}
+
+// changed schedules a check if the line changed since the last one.
+func (l *liveCheck) changed() {
+	if string(l.e.line) != l.line {
+		l.schedule()
+	}
+}
+
+// schedule cancels any pending check and starts a new one for the current
+// line after the delay.
+func (l *liveCheck) schedule() {
+	l.reset()
+	l.line = string(l.e.line)
+	gen, line := l.gen, l.line
+	ctx, cancel := context.WithCancel(l.ctx)
+	l.cancel = cancel
+	l.timer = time.AfterFunc(l.delay, func() {
+		stop := func() bool { return false }
+		if l.q.slowChecks {
+			stop = spin(func(frame string) {
+				l.e.mu.Lock()
+				defer l.e.mu.Unlock()
+				if l.stopped || gen != l.gen {
+					return
+				}
+				l.e.status, l.e.statusStyle = frame+" Checking...", hintStart
+				l.e.refresh()
+			})
+		}
+		err := l.q.checkLive(ctx, line)
+		stop()
+		l.e.mu.Lock()
+		defer l.e.mu.Unlock()
+		if l.stopped || gen != l.gen {
+			return
+		}
+		l.done, l.err = true, err
+		l.e.setStatus(err)
+		l.e.refresh()
+	})
+}
+
+// reset stops the pending check and makes any running check stale.
+func (l *liveCheck) reset() {
+	l.gen++
+	l.done, l.err = false, nil
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	if l.cancel != nil {
+		l.cancel()
+	}
+}
+
+// accept reports whether the line can be submitted, checking it right away
+// if the latest check hasn't finished.
+func (l *liveCheck) accept() bool {
+	if !l.done || l.line != string(l.e.line) {
+		l.reset()
+		l.line = string(l.e.line)
+		l.done, l.err = true, l.q.checkLive(l.ctx, l.line)
+	}
+	l.e.setStatus(l.err)
+	return l.err == nil
+}
+
+// passed reports whether input was accepted by the live checks, so they
+// don't need to run again.
+func (l *liveCheck) passed(input string) bool {
+	return l != nil && l.done && l.err == nil && l.line == input
+}
+
+// stop cancels any pending check.
+func (l *liveCheck) stop() {
+	l.e.mu.Lock()
+	defer l.e.mu.Unlock()
+	l.stopped = true
+	l.reset()
+}
+
+// checkLive runs the checks against line. An empty line is left to Ask,
+// which either uses the default or asks again.
+func (q *prompt) checkLive(ctx context.Context, line string) error {
+	line, err := q.transform(line)
+	if err != nil {
+		return err
+	}
+	if q.mask != nil && line != "" {
+		if _, err := q.mask.parse(line); err != nil {
+			return nil // Incomplete input is refused on Enter instead.
+		}
+		if line, err = q.maskValue(line); err != nil {
+			return err
+		}
+	}
+	if line == "" && (q.defaultValue() != "" || !q.optional) {
+		return nil
+	}
+	return q.runChecks(ctx, line)
+}
+
+// setStatus sets the status line to the error, or clears it.
+func (e *editor) setStatus(err error) {
+	e.status, e.statusStyle = "", statusStart
+	if err != nil {
+		e.status = err.Error()
+	}
+}
+
+// drawStatus prints the status line and any panel below the input, or
+// erases the rows that were printed before, then puts the cursor back. Rows
+// are counted from the row the input starts on.
+func (e *editor) drawStatus(width, cursorRow, cursorCol, endRow int) {
+	var rows []string
+	if e.status != "" {
+		status := []rune(strings.ReplaceAll(e.status, "\n", " "))
+		if width > 0 {
+			status = truncateWidth(status, width-1)
+		}
+		rows = append(rows, e.statusStyle+displayString(status)+statusEnd)
+	}
+	if e.panel != nil {
+		rows = append(rows, e.panel(width)...)
+	}
+	if len(rows) == 0 && !e.statusShown {
+		return
+	}
+	down := endRow - cursorRow + 1
+	if last := endRow + len(rows); len(rows) > 0 && last > e.reserved {
+		// Make room below the input once. Line feeds scroll the terminal
+		// if the input is on the last row, and the cursor moves back up
+		// with the text, so the rows above it keep their place.
+		n := last - cursorRow
+		fmt.Fprintf(e.w, "%s\x1b[%dA", strings.Repeat("\n", n), n)
+		e.reserved = last
+	}
+	// Cursor movements stop at the edge of the screen instead of scrolling.
+	fmt.Fprintf(e.w, "\x1b[%dB\r%s", down, strings.Join(rows, "\x1b[1B\r"))
+	up := down
+	if len(rows) > 1 {
+		up += len(rows) - 1
+	}
+	fmt.Fprintf(e.w, "\x1b[J\x1b[%dA\r", up)
+	if cursorCol > 0 {
+		fmt.Fprintf(e.w, "\x1b[%dC", cursorCol)
+	}
+	e.statusShown = len(rows) > 0
+}
+
+// truncateWidth returns the longest prefix of line that fits in width
+// columns.
+func truncateWidth(line []rune, width int) []rune {
+	used := 0
+	for i := 0; i < len(line); {
+		next := nextGrapheme(line, i)
+		used += clusterWidth(line[i:next])
+		if used > width {
+			return line[:i]
+		}
+		i = next
+	}
+	return line
+}
@@ -0,0 +1,135 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/matryer/is"
+)
+
+var errTooShort = errors.New("too short")
+
+func minLength(n int) fn {
+	return func(s string) error {
+		if len(s) < n {
+			return errTooShort
+		}
+		return nil
+	}
+}
+
+func TestDrawStatus(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.width = func() int { return 10 }
+	e.layout.width = 10
+
+	e.line, e.cursor = []rune("ab"), 1
+	e.status = "too short to be useful"
+	e.refresh()
+	// The row is made room for with a line feed the first time only.
+	is.Equal(writer.String(), "ab\r\x1b[3C\n\x1b[1A\x1b[1B\r\x1b[31mtoo short\x1b[39m\x1b[J\x1b[1A\r\x1b[3C")
+	is.True(e.statusShown)
+
+	writer.Reset()
+	e.status = "too long"
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[2Cab\r\x1b[3C\x1b[1B\r\x1b[31mtoo long\x1b[39m\x1b[J\x1b[1A\r\x1b[3C")
+
+	writer.Reset()
+	e.status = ""
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[2Cab\r\x1b[3C\x1b[1B\r\x1b[J\x1b[1A\r\x1b[3C")
+	is.True(!e.statusShown)
+
+	writer.Reset()
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[2Cab\r\x1b[3C")
+}
+
+func TestLiveCheckAccept(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithWriter(io.Discard), WithCheck(minLength(3)), WithLiveCheck(time.Hour))
+	e := newEditor(io.Discard, -1, "? ")
+	q.live = q.startLiveCheck(context.Background(), e)
+	defer q.live.stop()
+
+	e.insert([]rune("ab")...)
+	done, _, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(!done)
+	is.Equal(e.status, "too short")
+
+	e.insert('c')
+	done, input, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(done)
+	is.Equal(input, "abc")
+	is.Equal(e.status, "")
+	is.True(q.live.passed("abc"))
+	is.True(!q.live.passed("ab"))
+}
+
+func TestLiveCheckMaskFilter(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithWriter(io.Discard), WithMask("(999) 999-9999"),
+		WithAllowedRunes(unicode.IsDigit), WithMaxLength(10), WithLiveCheck(time.Hour))
+	e := newEditor(io.Discard, -1, "? ")
+	e.mask = q.mask
+	q.live = q.startLiveCheck(context.Background(), e)
+	defer q.live.stop()
+
+	e.insert([]rune("4155551234")...)
+	done, input, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(done)
+	is.Equal(input, "4155551234")
+	is.Equal(e.status, "")
+}
+
+func TestLiveCheckBackground(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	q := newPrompt(WithCheck(minLength(3)), WithLiveCheck(0))
+	e := newEditor(writer, -1, "? ")
+	q.live = q.startLiveCheck(context.Background(), e)
+	defer q.live.stop()
+
+	e.mu.Lock()
+	e.insert('a')
+	q.live.changed()
+	e.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		e.mu.Lock()
+		status := e.status
+		e.mu.Unlock()
+		if status != "" {
+			is.Equal(status, "too short")
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the live check")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	is.True(strings.Contains(writer.String(), "\x1b[31mtoo short\x1b[39m"))
+}
+
+func TestLiveCheckSkipsEmptyRequired(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithCheck(minLength(3)))
+	is.NoErr(q.checkLive(context.Background(), ""))
+	q = newPrompt(WithCheck(minLength(3)), WithOptional(true))
+	is.Equal(q.checkLive(context.Background(), ""), errTooShort)
+}
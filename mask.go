@@ -0,0 +1,238 @@
+package prompt
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WithMask formats the input with a pattern like "(999) 999-9999". In the
+// pattern, 9 is a digit, a is a letter, * is a letter or digit and h is a
+// hex digit. Anything else is a literal, which is filled in automatically;
+// escape a class character with a backslash to use it as a literal. The
+// input must fill every slot, and the formatted value is returned unless
+// WithMaskRaw is set.
+func WithMask(pattern string) Option {
+	return func(q *prompt) {
+		q.mask = parseMask(pattern)
+	}
+}
+
+// WithMaskRaw returns only the characters typed into a mask's slots, without
+// its literals.
+func WithMaskRaw(raw bool) Option {
+	return func(q *prompt) {
+		q.maskRaw = raw
+	}
+}
+
+// inputMask is a parsed mask pattern.
+type inputMask struct {
+	pattern string
+	parts   []maskPart
+	slots   int
+}
+
+// maskPart is either a slot for a class of rune or a literal rune.
+type maskPart struct {
+	class   rune // 0 for literals
+	literal rune
+}
+
+func parseMask(pattern string) *inputMask {
+	m := &inputMask{pattern: pattern}
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			m.parts = append(m.parts, maskPart{literal: r})
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '9', r == 'a', r == '*', r == 'h':
+			m.parts = append(m.parts, maskPart{class: r})
+			m.slots++
+		default:
+			m.parts = append(m.parts, maskPart{literal: r})
+		}
+	}
+	return m
+}
+
+// accepts reports whether r can fill a slot of the given class.
+func (p maskPart) accepts(r rune) bool {
+	switch p.class {
+	case '9':
+		return r >= '0' && r <= '9'
+	case 'a':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	case 'h':
+		return r < utf8.RuneSelf && isHexDigit(byte(r))
+	}
+	return false
+}
+
+// slot returns the part for the nth slot.
+func (m *inputMask) slot(n int) maskPart {
+	for _, part := range m.parts {
+		if part.class == 0 {
+			continue
+		}
+		if n == 0 {
+			return part
+		}
+		n--
+	}
+	return maskPart{}
+}
+
+// fits reports whether raw fills the first slots of the mask.
+func (m *inputMask) fits(raw []rune) bool {
+	if len(raw) > m.slots {
+		return false
+	}
+	for i, r := range raw {
+		if !m.slot(i).accepts(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// format places raw into the mask. Literals after the last filled slot are
+// included up to the next empty slot if trailing is set, so the cursor
+// skips over them.
+func (m *inputMask) format(raw []rune, trailing bool) []rune {
+	var out, pending []rune
+	i := 0
+	for _, part := range m.parts {
+		if part.class == 0 {
+			pending = append(pending, part.literal)
+			continue
+		}
+		if i >= len(raw) {
+			break
+		}
+		out = append(append(out, pending...), raw[i])
+		pending = pending[:0]
+		i++
+	}
+	if trailing || i == m.slots {
+		out = append(out, pending...)
+	}
+	return out
+}
+
+// template returns the rest of the mask after format(raw, true), with
+// empty slots shown as underscores.
+func (m *inputMask) template(raw []rune) []rune {
+	var out []rune
+	i := 0
+	started := false
+	for _, part := range m.parts {
+		if part.class != 0 {
+			if i < len(raw) {
+				i++
+				continue
+			}
+			started = true
+			out = append(out, '_')
+			continue
+		}
+		if started {
+			out = append(out, part.literal)
+		}
+	}
+	return out
+}
+
+// parse extracts the slot values from formatted or raw input.
+func (m *inputMask) parse(input string) ([]rune, error) {
+	raw, ok := m.scan(input)
+	if !ok || len(raw) != m.slots {
+		return nil, m.mismatch()
+	}
+	return raw, nil
+}
+
+// scan extracts the slot values from the start of formatted or raw input.
+// It stops at the first rune that's neither the next literal nor fits the
+// next slot, and reports whether all of input was read.
+func (m *inputMask) scan(input string) ([]rune, bool) {
+	var raw []rune
+	parts := m.parts
+	for _, r := range input {
+		// Skip literals, consuming r if it's the literal itself.
+		literal := false
+		for len(parts) > 0 && parts[0].class == 0 && !literal {
+			literal = r == parts[0].literal
+			parts = parts[1:]
+		}
+		if literal {
+			continue
+		}
+		if len(parts) == 0 || !parts[0].accepts(r) {
+			return raw, false
+		}
+		raw = append(raw, r)
+		parts = parts[1:]
+	}
+	return raw, true
+}
+
+func (m *inputMask) mismatch() error {
+	return fmt.Errorf("must match %s", m.pattern)
+}
+
+// shown returns the line as it's displayed and the cursor column within it.
+func (e *editor) shown() ([]rune, int) {
+	if e.mask == nil {
+		return e.line, stringWidth(e.line[:e.cursor])
+	}
+	return e.mask.format(e.line, true), stringWidth(e.mask.format(e.line[:e.cursor], true))
+}
+
+// fitMask returns the runes that can be inserted at the cursor while the
+// line still fits the mask.
+func (e *editor) fitMask(runes []rune) []rune {
+	var fitted []rune
+	for _, r := range runes {
+		candidate := make([]rune, 0, len(e.line)+len(fitted)+1)
+		candidate = append(candidate, e.line[:e.cursor]...)
+		candidate = append(candidate, fitted...)
+		candidate = append(candidate, r)
+		candidate = append(candidate, e.line[e.cursor:]...)
+		if e.mask.fits(candidate) {
+			fitted = append(fitted, r)
+		}
+	}
+	return fitted
+}
+
+// acceptMask reports whether the line fills the mask, so it can be
+// submitted. An empty line is left to Ask.
+func (q *prompt) acceptMask(e *editor) bool {
+	if q.mask == nil || len(e.line) == 0 || len(e.line) == q.mask.slots {
+		return true
+	}
+	e.setStatus(q.mask.mismatch())
+	return false
+}
+
+// maskValue checks input against the mask and returns the value to submit.
+// The allowed runes and maximum length apply to the slots, not the literals.
+func (q *prompt) maskValue(input string) (string, error) {
+	raw, err := q.mask.parse(input)
+	if err != nil {
+		return "", err
+	}
+	if err := q.checkRunes(string(raw)); err != nil {
+		return "", err
+	}
+	if q.maskRaw {
+		return string(raw), nil
+	}
+	return string(q.mask.format(raw, false)), nil
+}
@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMaskFormat(t *testing.T) {
+	is := is.New(t)
+	m := parseMask("(999) 999-9999")
+	is.Equal(m.slots, 10)
+	is.Equal(string(m.format(nil, true)), "(")
+	is.Equal(string(m.template(nil)), "___) ___-____")
+	is.Equal(string(m.format([]rune("415"), true)), "(415) ")
+	is.Equal(string(m.format([]rune("415"), false)), "(415")
+	is.Equal(string(m.template([]rune("415"))), "___-____")
+	is.Equal(string(m.format([]rune("4155551234"), false)), "(415) 555-1234")
+	is.Equal(string(m.template([]rune("4155551234"))), "")
+
+	is.True(m.fits([]rune("415")))
+	is.True(!m.fits([]rune("41a")))
+	is.True(!m.fits([]rune("41555512345")))
+}
+
+func TestMaskParse(t *testing.T) {
+	is := is.New(t)
+	m := parseMask(`hh:hh:hh:hh:hh:hh`)
+	raw, err := m.parse("00:1A:2b:3c:4d:5e")
+	is.NoErr(err)
+	is.Equal(string(raw), "001A2b3c4d5e")
+	raw, err = m.parse("001A2b3c4d5e")
+	is.NoErr(err)
+	is.Equal(string(raw), "001A2b3c4d5e")
+	_, err = m.parse("00:1A:2b")
+	is.Equal(err.Error(), "must match hh:hh:hh:hh:hh:hh")
+	_, err = m.parse("00:1A:2b:3c:4d:5g")
+	is.True(err != nil)
+
+	m = parseMask(`\a-999`)
+	is.Equal(m.slots, 3)
+	raw, err = m.parse("a-123")
+	is.NoErr(err)
+	is.Equal(string(raw), "123")
+}
+
+func TestMaskEditing(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.width = func() int { return 40 }
+	e.layout.width = 40
+	e.mask = parseMask("(999) 999-9999")
+
+	typeKeys(t, e, "(41x5")
+	is.Equal(string(e.line), "415")
+	e.refresh()
+	is.Equal(writer.String(), "(415) \x1b[90m___-____\x1b[39m\r\x1b[8C")
+
+	typeKeys(t, e, "\x7f\x7f")
+	is.Equal(string(e.line), "4")
+
+	typeKeys(t, e, "\x1b[200~15) 555-12345\x1b[201~")
+	is.Equal(string(e.line), "4155551234")
+
+	// The cursor moves over slots only.
+	typeKeys(t, e, "\x01\x06\x06\x06")
+	_, cursor := e.shown()
+	is.Equal(cursor, 6)
+}
+
+func TestMaskAccept(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithWriter(io.Discard), WithMask("99/99"))
+	e := newEditor(io.Discard, -1, "? ")
+	e.mask = q.mask
+	e.insert([]rune("123")...)
+
+	done, _, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(!done)
+	is.Equal(e.status, "must match 99/99")
+
+	e.insert('4')
+	done, input, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(done)
+	is.Equal(input, "1234")
+}
@@ -0,0 +1,73 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestAskMask(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := prompt.WithReader(bytes.NewBufferString("555-1234\n4155551234\n(415) 555-1234\n"))
+
+	phone, err := prompt.Ask(ctx, "Phone?", reader, prompt.WithWriter(writer), prompt.WithMask("(999) 999-9999"))
+	is.NoErr(err)
+	is.Equal(phone, "(415) 555-1234")
+	is.Equal(writer.String(), "Phone? must match (999) 999-9999\nPhone? ")
+
+	phone, err = prompt.Ask(ctx, "Phone?", reader, prompt.WithWriter(io.Discard),
+		prompt.WithMask("(999) 999-9999"),
+		prompt.WithMaskRaw(true),
+	)
+	is.NoErr(err)
+	is.Equal(phone, "4155551234")
+}
+
+func TestAskMaskFilter(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := prompt.WithReader(bytes.NewBufferString("(415) 555-1234\n"))
+
+	// The filters apply to the slots, not the mask's literals.
+	phone, err := prompt.Ask(ctx, "Phone?", reader, prompt.WithWriter(writer),
+		prompt.WithMask("(999) 999-9999"),
+		prompt.WithAllowedRunes(unicode.IsDigit),
+		prompt.WithMaxLength(10),
+	)
+	is.NoErr(err)
+	is.Equal(phone, "(415) 555-1234")
+	is.Equal(writer.String(), "Phone? ")
+
+	reader = prompt.WithReader(bytes.NewBufferString("(415) 555-1234\n(315) 555-1111\n"))
+	phone, err = prompt.Ask(ctx, "Phone?", reader, prompt.WithWriter(io.Discard),
+		prompt.WithMask("(999) 999-9999"),
+		prompt.WithAllowedRunes(func(r rune) bool { return r != '4' }),
+	)
+	is.NoErr(err)
+	is.Equal(phone, "(315) 555-1111")
+}
+
+func TestAskMaskTransform(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := prompt.WithReader(bytes.NewBufferString("+1 4155551234\n"))
+
+	// Transforms run before the mask.
+	phone, err := prompt.Ask(ctx, "Phone?", reader, prompt.WithWriter(io.Discard),
+		prompt.WithMask("(999) 999-9999"),
+		prompt.WithTransform(func(input string) (string, error) {
+			return strings.TrimPrefix(input, "+1 "), nil
+		}),
+	)
+	is.NoErr(err)
+	is.Equal(phone, "(415) 555-1234")
+}
@@ -0,0 +1,322 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WithNumberRange limits Int and Float to numbers between min and max,
+// inclusive. Numbers out of range are rejected unless WithClamp is set.
+func WithNumberRange(min, max float64) Option {
+	return func(q *prompt) {
+		q.minNumber, q.maxNumber = min, max
+	}
+}
+
+// WithClamp replaces numbers out of range with the nearest bound instead of
+// asking again.
+func WithClamp(clamp bool) Option {
+	return func(q *prompt) {
+		q.clamp = clamp
+	}
+}
+
+// WithStep sets how much Up and Down change a number in a terminal. Page Up
+// and Page Down change it by page, which defaults to ten steps.
+func WithStep(step, page float64) Option {
+	return func(q *prompt) {
+		q.step, q.pageStep = step, page
+	}
+}
+
+// WithPrecision rounds Float input to digits decimal places. By default the
+// number is returned as typed.
+func WithPrecision(digits int) Option {
+	return func(q *prompt) {
+		q.precision = digits
+	}
+}
+
+// Int asks for a whole number.
+func Int(ctx context.Context, prompt string, options ...Option) (int, error) {
+	q := newPrompt(options...)
+	return q.Int(ctx, prompt)
+}
+
+// Float asks for a number.
+func Float(ctx context.Context, prompt string, options ...Option) (float64, error) {
+	q := newPrompt(options...)
+	return q.Float(ctx, prompt)
+}
+
+// Int asks for a whole number. In a terminal, only digits and signs can be
+// typed, and Up/Down and Page Up/Down step the number.
+func (q *prompt) Int(ctx context.Context, prompt string) (int, error) {
+	n := q.newNumberInput(true)
+	input, err := q.askNumber(ctx, prompt, n)
+	if err != nil || input == "" {
+		return 0, err
+	}
+	i, err := n.parseInt(input)
+	return int(i), err
+}
+
+// Float asks for a number. In a terminal, Up/Down and Page Up/Down step the
+// number.
+func (q *prompt) Float(ctx context.Context, prompt string) (float64, error) {
+	n := q.newNumberInput(false)
+	input, err := q.askNumber(ctx, prompt, n)
+	if err != nil || input == "" {
+		return 0, err
+	}
+	return n.parse(input)
+}
+
+// askNumber asks for the input of a number prompt.
+func (q *prompt) askNumber(ctx context.Context, prompt string, n *numberInput) (string, error) {
+	q.numberInput = n
+	q.keymap = numberKeymap(q.keymap)
+	q.checks = append(q.checks, skipEmpty(n.check))
+	return q.Ask(ctx, prompt)
+}
+
+// numberKeymap adapts a keymap for stepping numbers.
+func numberKeymap(base *Keymap) *Keymap {
+	k := base.clone()
+	k.bindAll(ActionIncrement, "\x1b[A", "\x1bOA")
+	k.bindAll(ActionDecrement, "\x1b[B", "\x1bOB")
+	k.bindAll(ActionIncrementPage, "\x1b[5~")
+	k.bindAll(ActionDecrementPage, "\x1b[6~")
+	return k
+}
+
+// numberInput parses and steps numbers for Int and Float. Whole numbers
+// are kept as int64, so they don't lose precision.
+type numberInput struct {
+	integer   bool
+	min, max  float64
+	clamp     bool
+	step      float64
+	page      float64
+	precision int // decimal places, or -1 to keep the input's
+	ticks     []float64
+
+	minInt, maxInt   int64
+	stepInt, pageInt int64
+}
+
+func (q *prompt) newNumberInput(integer bool) *numberInput {
+	n := &numberInput{
+		integer:   integer,
+		min:       q.minNumber,
+		max:       q.maxNumber,
+		clamp:     q.clamp,
+		step:      q.step,
+		page:      q.pageStep,
+		precision: q.precision,
+		ticks:     q.ticks,
+	}
+	if n.min > n.max {
+		n.min, n.max = n.max, n.min
+	}
+	if n.step <= 0 {
+		n.step = 1
+	}
+	if n.page <= 0 {
+		n.page = 10 * n.step
+	}
+	if integer {
+		n.precision = 0
+		n.minInt, n.maxInt = toInt(math.Ceil(n.min)), toInt(math.Floor(n.max))
+		n.stepInt, n.pageInt = max(1, toInt(math.Round(n.step))), max(1, toInt(math.Round(n.page)))
+	}
+	return n
+}
+
+// toInt converts f to an int64, saturating at the limits of int.
+func toInt(f float64) int64 {
+	switch {
+	case f <= math.MinInt:
+		return math.MinInt
+	case f >= math.MaxInt:
+		return math.MaxInt
+	}
+	return int64(f)
+}
+
+// check reports whether input is a valid number.
+func (n *numberInput) check(input string) error {
+	if n.integer {
+		_, err := n.parseInt(input)
+		return err
+	}
+	_, err := n.parse(input)
+	return err
+}
+
+// intValue reads a whole number without checking its range. Numbers too
+// big for int64 are returned at the nearest limit along with an error.
+func (n *numberInput) intValue(input string) (int64, error) {
+	i, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return i, errOutOfRange
+	}
+	if err != nil {
+		return 0, errors.New("must be a whole number")
+	}
+	return i, nil
+}
+
+var errOutOfRange = errors.New("out of range")
+
+// parseInt reads a whole number and checks that it's in range, clamping it
+// if that's enabled.
+func (n *numberInput) parseInt(input string) (int64, error) {
+	i, err := n.intValue(input)
+	if err != nil && err != errOutOfRange {
+		return 0, err
+	}
+	if err == nil && i >= n.minInt && i <= n.maxInt {
+		return i, nil
+	}
+	if n.clamp {
+		return min(max(i, n.minInt), n.maxInt), nil
+	}
+	return 0, n.rangeError()
+}
+
+// value reads a number without checking its range.
+func (n *numberInput) value(input string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, errors.New("must be a number")
+	}
+	return n.round(f, n.precision), nil
+}
+
+// parse reads a number and checks that it's in range, clamping it if
+// that's enabled.
+func (n *numberInput) parse(input string) (float64, error) {
+	v, err := n.value(input)
+	if err != nil {
+		return 0, err
+	}
+	if v >= n.min && v <= n.max {
+		return v, nil
+	}
+	if n.clamp {
+		return n.limit(v), nil
+	}
+	return 0, n.rangeError()
+}
+
+func (n *numberInput) rangeError() error {
+	low, high := n.format(n.min), n.format(n.max)
+	if n.integer {
+		low, high = strconv.FormatInt(n.minInt, 10), strconv.FormatInt(n.maxInt, 10)
+	}
+	// Whole numbers are limited to int even without a range.
+	switch {
+	case math.IsInf(n.max, 1) && !math.IsInf(n.min, -1):
+		return fmt.Errorf("must be at least %s", low)
+	case math.IsInf(n.min, -1) && !math.IsInf(n.max, 1):
+		return fmt.Errorf("must be at most %s", high)
+	}
+	return fmt.Errorf("must be between %s and %s", low, high)
+}
+
+// limit returns the nearest number in range.
+func (n *numberInput) limit(v float64) float64 {
+	return math.Min(math.Max(v, n.min), n.max)
+}
+
+// round rounds v to digits decimal places, unless digits is negative.
+func (n *numberInput) round(v float64, digits int) float64 {
+	if digits < 0 {
+		return v
+	}
+	scale := math.Pow(10, float64(digits))
+	return math.Round(v*scale) / scale
+}
+
+func (n *numberInput) format(v float64) string {
+	return strconv.FormatFloat(v, 'f', n.precision, 64)
+}
+
+// allows reports whether r can be typed into a number.
+func (n *numberInput) allows(r rune) bool {
+	switch {
+	case r >= '0' && r <= '9', r == '-', r == '+':
+		return true
+	case n.integer:
+		return false
+	}
+	return r == '.' || r == 'e' || r == 'E'
+}
+
+// add steps the number in line by delta, staying in range. An empty or
+// invalid line starts from zero.
+func (n *numberInput) add(line string, delta float64) string {
+	v, err := n.value(line)
+	if err != nil {
+		v = 0
+	}
+	digits := n.precision
+	if digits < 0 {
+		// Keep as many decimals as the number or the step has.
+		digits = max(decimals(v), decimals(delta))
+	}
+	return n.format(n.limit(n.round(v+delta, digits)))
+}
+
+// addInt steps the whole number in line by delta, staying in range.
+func (n *numberInput) addInt(line string, delta int64) string {
+	i, err := n.intValue(line)
+	if err != nil && err != errOutOfRange {
+		i = 0
+	}
+	sum := i + delta
+	switch {
+	case delta > 0 && sum < i:
+		sum = math.MaxInt64
+	case delta < 0 && sum > i:
+		sum = math.MinInt64
+	}
+	return strconv.FormatInt(min(max(sum, n.minInt), n.maxInt), 10)
+}
+
+// decimals returns the number of decimal places v is written with.
+func decimals(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// stepNumber changes the number on the line by steps, or by pages of steps
+// if page is set.
+func (e *editor) stepNumber(steps int, page bool) {
+	n := e.numberInput
+	if n == nil {
+		return
+	}
+	if n.integer {
+		delta := n.stepInt
+		if page {
+			delta = n.pageInt
+		}
+		e.line = []rune(n.addInt(string(e.line), int64(steps)*delta))
+	} else {
+		delta := n.step
+		if page {
+			delta = n.page
+		}
+		e.line = []rune(n.add(string(e.line), float64(steps)*delta))
+	}
+	e.cursor = len(e.line)
+}
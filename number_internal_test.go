@@ -0,0 +1,142 @@
+package prompt
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNumberParse(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt(WithNumberRange(-10.5, 10)).newNumberInput(true)
+	i, err := n.parseInt(" -7 ")
+	is.NoErr(err)
+	is.Equal(i, int64(-7))
+	_, err = n.parseInt("7.5")
+	is.Equal(err.Error(), "must be a whole number")
+	_, err = n.parseInt("11")
+	is.Equal(err.Error(), "must be between -10 and 10")
+
+	n = newPrompt(WithNumberRange(0, math.Inf(1))).newNumberInput(false)
+	_, err = n.parse("-0.5")
+	is.Equal(err.Error(), "must be at least 0")
+	_, err = n.parse("NaN")
+	is.Equal(err.Error(), "must be a number")
+	_, err = n.parse("1e400")
+	is.Equal(err.Error(), "must be a number")
+
+	n = newPrompt(WithNumberRange(math.Inf(-1), 1.5)).newNumberInput(false)
+	_, err = n.parse("2")
+	is.Equal(err.Error(), "must be at most 1.5")
+}
+
+func TestNumberClamp(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt(WithNumberRange(1, 5), WithClamp(true)).newNumberInput(true)
+	i, err := n.parseInt("99")
+	is.NoErr(err)
+	is.Equal(i, int64(5))
+	i, err = n.parseInt("-3")
+	is.NoErr(err)
+	is.Equal(i, int64(1))
+	i, err = n.parseInt("99999999999999999999")
+	is.NoErr(err)
+	is.Equal(i, int64(5))
+}
+
+func TestNumberLargeInt(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("int is too small")
+	}
+	is := is.New(t)
+	// Whole numbers beyond 2^53 keep their value.
+	n := newPrompt().newNumberInput(true)
+	i, err := n.parseInt("9007199254740993")
+	is.NoErr(err)
+	is.Equal(i, int64(9007199254740993))
+	is.Equal(n.addInt("9007199254740993", 1), "9007199254740994")
+
+	// Stepping saturates instead of overflowing.
+	is.Equal(n.addInt("9223372036854775807", 1), "9223372036854775807")
+	is.Equal(n.addInt("-9223372036854775808", -10), "-9223372036854775808")
+
+	// Numbers too big for int64 are out of range.
+	_, err = n.parseInt("99999999999999999999")
+	is.Equal(err.Error(), "must be between -9223372036854775808 and 9223372036854775807")
+
+	n = newPrompt(WithNumberRange(0, math.Inf(1))).newNumberInput(true)
+	_, err = n.parseInt("-1")
+	is.Equal(err.Error(), "must be at least 0")
+}
+
+func TestNumberPrecision(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt(WithPrecision(2)).newNumberInput(false)
+	v, err := n.parse("3.14159")
+	is.NoErr(err)
+	is.Equal(v, 3.14)
+	is.Equal(n.format(2.5), "2.50")
+
+	// Without a precision, the number is kept as typed.
+	n = newPrompt().newNumberInput(false)
+	v, err = n.parse("3.14159")
+	is.NoErr(err)
+	is.Equal(v, 3.14159)
+}
+
+func TestNumberAdd(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt(WithNumberRange(0, 100)).newNumberInput(true)
+	is.Equal(n.addInt("", 1), "1")
+	is.Equal(n.addInt("", -1), "0")
+	is.Equal(n.addInt("42", 10), "52")
+	is.Equal(n.addInt("95", 10), "100")
+	// Out of range input steps back into range.
+	is.Equal(n.addInt("500", -1), "100")
+	is.Equal(n.addInt("abc", 1), "1")
+
+	// Floats don't pick up rounding errors.
+	n = newPrompt(WithStep(0.1, 0)).newNumberInput(false)
+	is.Equal(n.page, 1.0)
+	is.Equal(n.add("0.2", 0.1), "0.3")
+	is.Equal(n.add("1.25", 0.1), "1.35")
+
+	n = newPrompt(WithPrecision(2), WithStep(0.5, 0)).newNumberInput(false)
+	is.Equal(n.add("1", 0.5), "1.50")
+}
+
+func TestNumberIntegerSteps(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt(WithStep(0.2, 2.6)).newNumberInput(true)
+	is.Equal(n.stepInt, int64(1))
+	is.Equal(n.pageInt, int64(3))
+}
+
+func TestNumberAllows(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt().newNumberInput(true)
+	is.True(n.allows('7'))
+	is.True(n.allows('-'))
+	is.True(!n.allows('.'))
+	is.True(!n.allows('x'))
+	n = newPrompt().newNumberInput(false)
+	is.True(n.allows('.'))
+	is.True(n.allows('e'))
+	is.True(!n.allows('x'))
+}
+
+func TestStepNumber(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.numberInput = newPrompt(WithStep(5, 50)).newNumberInput(true)
+	typeKeys(t, e, "1\x1b[A\x1b[A\x1b[5~\x1b[B", func(q *prompt) { q.keymap = numberKeymap(q.keymap) })
+	is.Equal(string(e.line), "56")
+	is.Equal(e.cursor, 2)
+
+	// Undo restores the line before the step.
+	typeKeys(t, e, "\x1f", func(q *prompt) { q.keymap = numberKeymap(q.keymap) })
+	is.Equal(string(e.line), "61")
+}
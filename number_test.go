@@ -0,0 +1,92 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestInt(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	replicas, err := prompt.Int(ctx, "Replicas?",
+		prompt.WithReader(bytes.NewBufferString("three\n0\n3\n")),
+		prompt.WithWriter(writer),
+		prompt.WithNumberRange(1, 10),
+	)
+	is.NoErr(err)
+	is.Equal(replicas, 3)
+	diff.TestString(t, writer.String(), "Replicas? must be a whole number\n"+
+		"Replicas? must be between 1 and 10\n"+
+		"Replicas? ")
+}
+
+func TestIntLarge(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("int is too small")
+	}
+	is := is.New(t)
+	ctx := context.Background()
+	n, err := prompt.Int(ctx, "Seed?",
+		prompt.WithReader(bytes.NewBufferString("9007199254740993\n")),
+		prompt.WithWriter(io.Discard),
+	)
+	is.NoErr(err)
+	is.Equal(int64(n), int64(9007199254740993))
+}
+
+func TestIntClamp(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	replicas, err := prompt.Int(ctx, "Replicas?",
+		prompt.WithReader(bytes.NewBufferString("50\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithNumberRange(1, 10),
+		prompt.WithClamp(true),
+	)
+	is.NoErr(err)
+	is.Equal(replicas, 10)
+}
+
+func TestIntDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	replicas, err := prompt.Int(ctx, "Replicas?",
+		prompt.WithReader(bytes.NewBufferString("\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithDefault("2"),
+	)
+	is.NoErr(err)
+	is.Equal(replicas, 2)
+}
+
+func TestFloat(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ratio, err := prompt.Float(ctx, "Ratio?",
+		prompt.WithReader(bytes.NewBufferString("0.3333\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithPrecision(2),
+	)
+	is.NoErr(err)
+	is.Equal(ratio, 0.33)
+}
+
+func TestFloatOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ratio, err := prompt.Float(ctx, "Ratio?",
+		prompt.WithReader(bytes.NewBufferString("\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithOptional(true),
+	)
+	is.NoErr(err)
+	is.Equal(ratio, 0.0)
+}
@@ -0,0 +1,136 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	pasteStart = "[200~"
+	pasteEnd   = "\x1b[201~"
+
+	enableBracketedPaste  = "\x1b[?2004h"
+	disableBracketedPaste = "\x1b[?2004l"
+
+	defaultPasteLimit = 64 * 1024
+)
+
+// PastePolicy controls what happens to newlines in pasted text. The default
+// is PasteKeep.
+type PastePolicy int
+
+const (
+	// PasteJoin replaces newlines with spaces.
+	PasteJoin PastePolicy = iota
+	// PasteReject discards pastes that contain newlines.
+	PasteReject
+	// PasteKeep keeps newlines in multi-line prompts. Single-line prompts
+	// join them instead.
+	PasteKeep
+)
+
+// WithPaste sets how newlines in pasted text are handled.
+func WithPaste(policy PastePolicy) Option {
+	return func(q *prompt) {
+		q.pastePolicy = policy
+	}
+}
+
+// WithPasteLimit sets the maximum number of bytes accepted from a single
+// paste. Anything beyond the limit is dropped.
+func WithPasteLimit(limit int) Option {
+	return func(q *prompt) {
+		q.pasteLimit = limit
+	}
+}
+
+// readPaste reads bracketed paste content up to the end marker, keeping at
+// most limit bytes. It reports whether the paste was truncated.
+func readPaste(r *bufio.Reader, limit int) (string, bool, error) {
+	var buf bytes.Buffer
+	var tail []byte
+	truncated := false
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", false, err
+		}
+		tail = append(tail, b)
+		if !strings.HasPrefix(pasteEnd, string(tail)) {
+			// Flush everything but the longest suffix that could still start
+			// the end marker.
+			keep := 0
+			for i := 1; i < len(tail); i++ {
+				if strings.HasPrefix(pasteEnd, string(tail[i:])) {
+					keep = len(tail) - i
+					break
+				}
+			}
+			for _, c := range tail[:len(tail)-keep] {
+				if limit > 0 && buf.Len() >= limit {
+					truncated = true
+					continue
+				}
+				buf.WriteByte(c)
+			}
+			tail = tail[len(tail)-keep:]
+			continue
+		}
+		if len(tail) == len(pasteEnd) {
+			break
+		}
+	}
+	content := buf.Bytes()
+	if truncated {
+		content = trimPartialRune(content)
+	}
+	return string(content), truncated, nil
+}
+
+// trimPartialRune drops an incomplete rune that the paste limit cut off at
+// the end of b. Other invalid bytes are left for cleanPaste.
+func trimPartialRune(b []byte) []byte {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return b[:i]
+			}
+			break
+		}
+	}
+	return b
+}
+
+// cleanPaste turns pasted text into runes to insert, applying the newline
+// policy and dropping other control characters. Invalid UTF-8 becomes
+// U+FFFD. It returns false if the paste is rejected.
+func cleanPaste(text string, policy PastePolicy, multiline bool) ([]rune, bool) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	if policy == PasteReject && strings.Contains(text, "\n") {
+		return nil, false
+	}
+	keep := policy == PasteKeep && multiline
+	runes := make([]rune, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\n' && keep:
+			runes = append(runes, r)
+		case r == '\n', r == '\t':
+			runes = append(runes, ' ')
+		case unicode.IsControl(r):
+			continue
+		default:
+			runes = append(runes, r)
+		}
+	}
+	return runes, true
+}
@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestReadPaste(t *testing.T) {
+	is := is.New(t)
+	r := bufio.NewReader(strings.NewReader("a\x1b[Db\x1b[201~c"))
+
+	text, truncated, err := readPaste(r, 0)
+	is.NoErr(err)
+	is.True(!truncated)
+	is.Equal(text, "a\x1b[Db")
+
+	b, err := r.ReadByte()
+	is.NoErr(err)
+	is.Equal(b, byte('c'))
+}
+
+func TestReadPasteLimit(t *testing.T) {
+	is := is.New(t)
+	r := bufio.NewReader(strings.NewReader("héllo\x1b[201~"))
+
+	text, truncated, err := readPaste(r, 2)
+	is.NoErr(err)
+	is.True(truncated)
+	is.Equal(text, "h")
+}
+
+func TestReadPasteInvalidUTF8(t *testing.T) {
+	is := is.New(t)
+	r := bufio.NewReader(strings.NewReader("abc\xffdef\x1b[201~"))
+
+	// An invalid byte in the middle doesn't cut the paste short.
+	text, truncated, err := readPaste(r, 0)
+	is.NoErr(err)
+	is.True(!truncated)
+	is.Equal(text, "abc\xffdef")
+
+	runes, ok := cleanPaste(text, PasteKeep, false)
+	is.True(ok)
+	is.Equal(string(runes), "abc\uFFFDdef")
+
+	// A trailing invalid byte is kept unless the limit cut the paste.
+	r = bufio.NewReader(strings.NewReader("abc\xff\x1b[201~"))
+	text, _, err = readPaste(r, 0)
+	is.NoErr(err)
+	is.Equal(text, "abc\xff")
+}
+
+func TestCleanPaste(t *testing.T) {
+	is := is.New(t)
+
+	runes, ok := cleanPaste("one\r\ntwo\tthree\x07", PasteJoin, false)
+	is.True(ok)
+	is.Equal(string(runes), "one two three")
+
+	_, ok = cleanPaste("one\ntwo", PasteReject, false)
+	is.True(!ok)
+
+	runes, ok = cleanPaste("one\ntwo", PasteKeep, false)
+	is.True(ok)
+	is.Equal(string(runes), "one two")
+
+	runes, ok = cleanPaste("one\ntwo", PasteKeep, true)
+	is.True(ok)
+	is.Equal(string(runes), "one\ntwo")
+}
+
+func TestHandleKeyPaste(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	q := newPrompt(
+		WithReader(strings.NewReader("[200~hello\nworld\x1b[201~")),
+		WithWriter(writer),
+	)
+	e := newEditor(writer, -1, "? ")
+	e.line, e.cursor = []rune("<>"), 1
+
+	done, _, err := q.handleKey(context.Background(), e, 0x1b)
+	is.NoErr(err)
+	is.True(!done)
+	is.Equal(string(e.line), "<hello world>")
+	is.Equal(e.cursor, 12)
+}
+
+func TestHandleKeyPasteRejected(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	q := newPrompt(
+		WithReader(strings.NewReader("[200~rm -rf\n\x1b[201~")),
+		WithWriter(writer),
+		WithPaste(PasteReject),
+	)
+	e := newEditor(writer, -1, "? ")
+
+	done, _, err := q.handleKey(context.Background(), e, 0x1b)
+	is.NoErr(err)
+	is.True(!done)
+	is.Equal(string(e.line), "")
+	is.Equal(writer.String(), "\a")
+}
@@ -0,0 +1,41 @@
+package prompt
+
+const (
+	hintStart = "\x1b[90m"
+	hintEnd   = "\x1b[39m"
+)
+
+// WithPlaceholder shows a dimmed hint in the input area while the input is
+// empty, like "e.g. https://example.com". It's only shown in terminals and
+// is never submitted as input.
+func WithPlaceholder(placeholder string) Option {
+	return func(q *prompt) {
+		q.placeholder = placeholder
+	}
+}
+
+// hint returns the dimmed text to show after the input: the empty slots of
+// a mask, the placeholder while the input is empty, otherwise the rest of
+// the suggestion.
+func (e *editor) hint() []rune {
+	if e.mask != nil {
+		return e.mask.template(e.line)
+	}
+	if len(e.line) == 0 {
+		return []rune(e.placeholder)
+	}
+	return e.suggestion()
+}
+
+// clearHint hides any placeholder, suggestion, status line or panel before
+// the prompt finishes.
+func (e *editor) clearHint() {
+	if e.suggest == nil && e.placeholder == "" && e.panel == nil && !e.statusShown {
+		return
+	}
+	e.suggest = nil
+	e.placeholder = ""
+	e.panel = nil
+	e.status = ""
+	e.refresh()
+}
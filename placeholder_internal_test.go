@@ -0,0 +1,60 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPlaceholder(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.width = func() int { return 20 }
+	e.layout.width = 20
+	e.placeholder = "e.g. x.com"
+
+	e.refresh()
+	is.Equal(writer.String(), "\x1b[90me.g. x.com\x1b[39m\r\x1b[2C")
+	is.Equal(e.layout.lineWidth, 10)
+
+	// The first keystroke replaces the placeholder.
+	writer.Reset()
+	e.line, e.cursor = []rune("a"), 1
+	e.refresh()
+	is.Equal(writer.String(), "a         \r\x1b[3C")
+
+	// Clearing the line brings it back.
+	writer.Reset()
+	e.line, e.cursor = []rune{}, 0
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[2C\x1b[90me.g. x.com\x1b[39m\r\x1b[2C")
+}
+
+func TestPlaceholderClearedOnAccept(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newEditor(writer, -1, "? ")
+	e.placeholder = "name"
+	e.refresh()
+
+	writer.Reset()
+	q := typeKeys(t, e, "")
+	done, input, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(done)
+	is.Equal(input, "")
+	is.Equal(writer.String(), "\x1b[K")
+}
+
+func TestPlaceholderText(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newTextEditor("? ", 80)
+	e.w = writer
+	e.placeholder = "Describe the bug"
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[J? \x1b[90mDescribe the bug\x1b[39m\r\x1b[2C")
+}
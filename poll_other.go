@@ -0,0 +1,12 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package prompt
+
+import "time"
+
+const canPoll = false
+
+// waitReadable is unsupported on this platform, so it never waits.
+func waitReadable(fd int, timeout time.Duration) bool {
+	return false
+}
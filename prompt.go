@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -17,6 +19,11 @@ import (
 // ErrRequired is returned when a required input is empty
 var ErrRequired = fmt.Errorf("prompt: input is required")
 
+// ErrEOF is returned when the input ends before a required input is given,
+// like when stdin is closed or Ctrl+D is pressed on an empty line. It wraps
+// io.EOF.
+var ErrEOF = fmt.Errorf("prompt: unexpected end of input: %w", io.EOF)
+
 // ErrInterrupted is returned when a terminal prompt is interrupted (Ctrl+C).
 var ErrInterrupted = fmt.Errorf("prompt: interrupted")
 
@@ -43,6 +50,14 @@ func WithDefault(defaultTo string) Option {
 	}
 }
 
+// WithInitialValue prefills the input so it can be edited in place. Without
+// a terminal to edit in, it's used like a default when the input is empty.
+func WithInitialValue(value string) Option {
+	return func(q *prompt) {
+		q.initialValue = value
+	}
+}
+
 // WithOptional marks a question as optional.
 func WithOptional(optional bool) Option {
 	return func(q *prompt) {
@@ -53,7 +68,11 @@ func WithOptional(optional bool) Option {
 // WithCheck appends checks for a question.
 func WithCheck(checks ...fn) Option {
 	return func(q *prompt) {
-		q.checks = append(q.checks, checks...)
+		for _, check := range checks {
+			q.checks = append(q.checks, func(_ context.Context, input string) error {
+				return check(input)
+			})
+		}
 	}
 }
 
@@ -83,6 +102,16 @@ func WithReader(r io.Reader) Option {
 	}
 }
 
+// WithCursorQuery asks the terminal where the cursor is before editing,
+// waiting up to timeout for an answer. This keeps redraws anchored when the
+// prompt doesn't start at the beginning of a line. If the terminal doesn't
+// answer in time, the prompt is assumed to start at the first column.
+func WithCursorQuery(timeout time.Duration) Option {
+	return func(q *prompt) {
+		q.cursorQuery = timeout
+	}
+}
+
 // Ask asks a question and returns the input.
 func Ask(ctx context.Context, prompt string, options ...Option) (string, error) {
 	q := newPrompt(options...)
@@ -106,16 +135,68 @@ type prompt struct {
 	writer    io.Writer
 	reader    *bufio.Reader
 	fd        int
-	checks    []fn
+	checks    []ctxFn
 	defaultTo string
 	optional  bool
+
+	cursorQuery   time.Duration
+	pastePolicy   PastePolicy
+	pasteLimit    int
+	killRing      *KillRing
+	editMode      EditMode
+	keymap        *Keymap
+	history       *History
+	submitKey     string
+	terminator    string
+	multiline     bool
+	template      string
+	cooked        *term.State // terminal state to restore while an editor runs
+	suggestions   bool
+	suggester     func(string) string
+	placeholder   string
+	initialValue  string
+	liveCheck     bool
+	liveDelay     time.Duration
+	live          *liveCheck
+	slowChecks    bool
+	transforms    []func(string) (string, error)
+	echoTransform bool
+	transformed   bool // the input was transformed while editing
+	maxAttempts   int
+	eof           bool // the reader has reached the end of the input
+	allowedRunes  func(rune) bool
+	maxLength     int
+	bell          bool
+	mask          *inputMask
+	maskRaw       bool
+	minDate       time.Time
+	maxDate       time.Time
+	location      *time.Location
+	now           func() time.Time
+	calendar      *calendar
+	minNumber     float64
+	maxNumber     float64
+	clamp         bool
+	step          float64
+	pageStep      float64
+	precision     int
+	numberInput   *numberInput
+	slider        bool
+	ticks         []float64
 }
 
 func newPrompt(options ...Option) *prompt {
 	q := &prompt{
-		writer: os.Stdout,
-		reader: bufio.NewReader(os.Stdin),
-		fd:     getFd(os.Stdin),
+		writer:      os.Stdout,
+		reader:      bufio.NewReader(os.Stdin),
+		fd:          getFd(os.Stdin),
+		pastePolicy: PasteKeep,
+		pasteLimit:  defaultPasteLimit,
+		keymap:      defaultKeymap,
+		submitKey:   KeyCtrlD,
+		minNumber:   math.Inf(-1),
+		maxNumber:   math.Inf(1),
+		precision:   -1,
 	}
 	for _, option := range options {
 		if option == nil {
@@ -130,6 +211,15 @@ func (q *prompt) isTerminal() bool {
 	return q.fd > -1 && term.IsTerminal(q.fd)
 }
 
+// defaultValue returns the value used when the input is empty. Without a
+// terminal, the initial value can't be edited, so it acts as a default.
+func (q *prompt) defaultValue() string {
+	if q.defaultTo == "" && !q.isTerminal() {
+		return q.initialValue
+	}
+	return q.defaultTo
+}
+
 func (q *prompt) scanLine(inputCh chan<- string, errorCh chan<- error) {
 	// Read the input.
 	input, err := q.reader.ReadString('\n')
@@ -138,13 +228,12 @@ func (q *prompt) scanLine(inputCh chan<- string, errorCh chan<- error) {
 			errorCh <- err
 			return
 		}
-		// If we're at the end of the input, and there is a default, use it,
-		// otherwise return a required error.
-		if q.defaultTo != "" {
-			inputCh <- q.defaultTo
-			return
-		} else if !q.optional {
-			errorCh <- ErrRequired
+		// If we're at the end of the input, use what's left of the last line,
+		// then the default, otherwise return an EOF error.
+		q.eof = true
+		input, err = q.eofValue(strings.TrimRight(input, "\r\n"))
+		if err != nil {
+			errorCh <- err
 			return
 		}
 	}
@@ -154,15 +243,67 @@ func (q *prompt) scanLine(inputCh chan<- string, errorCh chan<- error) {
 	inputCh <- input
 }
 
-func (q *prompt) readTerminalLine(inputOffset int) (string, error) {
+func (q *prompt) readTerminalLine(ctx context.Context, promptText string) (string, error) {
 	state, err := term.MakeRaw(q.fd)
 	if err != nil {
 		return "", err
 	}
 	defer term.Restore(q.fd, state)
-
-	line := []rune{}
-	cursor := 0
+	q.cooked = state
+	q.transformed = false
+	fmt.Fprint(q.writer, enableBracketedPaste)
+	defer fmt.Fprint(q.writer, disableBracketedPaste)
+
+	e := newEditor(q.writer, q.fd, promptText)
+	e.multiline = q.multiline
+	if q.killRing != nil {
+		e.kills = q.killRing
+	}
+	e.history = q.history
+	if q.suggestions {
+		e.suggest = q.suggestion
+	}
+	e.placeholder = q.placeholder
+	if q.allowedRunes != nil {
+		e.allows = q.allows
+	}
+	e.maxLength, e.bell = q.maxLength, q.bell
+	e.mask = q.mask
+	if q.calendar != nil {
+		e.calendar = q.calendar
+		e.panel = func(width int) []string { return q.calendar.lines(string(e.line), width) }
+	}
+	if q.numberInput != nil {
+		e.numberInput = q.numberInput
+		if e.allows == nil {
+			e.allows = q.numberInput.allows
+		}
+		if q.slider {
+			e.panel = func(width int) []string { return q.numberInput.bar(string(e.line), width) }
+		}
+	}
+	// Anchor to the printed prompt before anything is repainted.
+	if q.cursorQuery > 0 {
+		if col, ok := queryCursorColumn(q.writer, q.reader, q.fd, q.cursorQuery); ok {
+			e.anchor(col)
+		}
+	}
+	if q.editMode == ViMode {
+		e.startVi()
+		e.refresh()
+	}
+	if q.initialValue != "" {
+		e.insert([]rune(q.initialValue)...)
+	}
+	if len(e.line) > 0 || e.placeholder != "" || e.panel != nil {
+		e.refresh()
+	}
+	stopResize := e.watchResize()
+	defer stopResize()
+	if q.liveCheck {
+		q.live = q.startLiveCheck(ctx, e)
+		defer q.live.stop()
+	}
 
 	for {
 		b, err := q.reader.ReadByte()
@@ -170,73 +311,178 @@ func (q *prompt) readTerminalLine(inputOffset int) (string, error) {
 			if !errors.Is(err, io.EOF) {
 				return "", err
 			}
-			return q.eofValue(string(line))
-		}
-
-		oldCursor := cursor
-		oldLen := len(line)
-		switch b {
-		case '\r', '\n':
-			fmt.Fprint(q.writer, "\r\n")
-			return string(line), nil
-		case 0x03: // Ctrl+C
-			return "", handleInterrupt(q.writer)
-		case 0x01: // Ctrl+A
-			cursor = 0
-		case 0x02: // Ctrl+B
-			if cursor > 0 {
-				cursor--
-			}
-		case 0x05: // Ctrl+E
-			cursor = len(line)
-		case 0x06: // Ctrl+F
-			if cursor < len(line) {
-				cursor++
-			}
-		case 0x0b: // Ctrl+K
-			line = line[:cursor]
-		case 0x15: // Ctrl+U
-			line, cursor = backwardKillLine(line, cursor)
-		case 0x17: // Ctrl+W
-			line, cursor = backwardKillWord(line, cursor)
-		case 0x04: // Ctrl+D
-			if len(line) == 0 {
-				return q.eofValue("")
-			}
-			if cursor < len(line) {
-				line = append(line[:cursor], line[cursor+1:]...)
-			}
-		case 0x08, 0x7f: // Backspace
-			if cursor > 0 {
-				line = append(line[:cursor-1], line[cursor:]...)
-				cursor--
-			}
-		case 0x1b: // Escape sequence
-			seq, err := readEscapeSequence(q.reader)
-			if err != nil {
-				return "", err
+			return q.eofValue(string(e.line))
+		}
+
+		e.mu.Lock()
+		done, input, err := q.editKey(ctx, e, b)
+		if !done {
+			if q.live != nil {
+				q.live.changed()
 			}
-			line, cursor = applyEscapeSequence(seq, line, cursor)
-		default:
+			e.refresh()
+		}
+		e.mu.Unlock()
+		if done {
+			return input, err
+		}
+	}
+}
+
+// editKey dispatches the key starting with b to the key handler for the
+// edit mode and records an undo step if the line changed. It reports whether
+// editing has finished along with the resulting input.
+func (q *prompt) editKey(ctx context.Context, e *editor, b byte) (bool, string, error) {
+	e.lastAction, e.action = e.action, actOther
+	before := e.snapshot()
+	handle := q.handleKey
+	if q.editMode == ViMode {
+		handle = q.handleViKey
+	}
+	done, input, err := handle(ctx, e, b)
+	e.record(before)
+	return done, input, err
+}
+
+// handleKey reads the rest of the key starting with b and runs the action
+// bound to it in the keymap. Unbound printable keys insert themselves.
+func (q *prompt) handleKey(ctx context.Context, e *editor, b byte) (bool, string, error) {
+	key, err := q.readKey(b)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			input, err := q.eofValue(string(e.line))
+			return true, input, err
+		}
+		return true, "", err
+	}
+	action, ok := q.keymap.Lookup(key)
+	if !ok {
+		r, size := utf8.DecodeRuneInString(key)
+		if size == len(key) && !unicode.IsControl(r) {
+			e.insert(r)
+			e.action = actInsert
+		}
+		return false, "", nil
+	}
+	return q.runAction(ctx, e, action)
+}
+
+// readKey reads a complete key sequence starting with b, continuing through
+// chords like Ctrl+X Ctrl+U while the keymap has longer bindings.
+func (q *prompt) readKey(b byte) (string, error) {
+	key, err := q.readSingleKey(b)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, ok := q.keymap.Lookup(key); ok || !q.keymap.isPrefix(key) {
+			return key, nil
+		}
+		next, err := q.reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		more, err := q.readSingleKey(next)
+		if err != nil {
+			return "", err
+		}
+		key += more
+	}
+}
+
+// readSingleKey reads one key: a byte, a UTF-8 encoded rune or an escape
+// sequence.
+func (q *prompt) readSingleKey(b byte) (string, error) {
+	switch {
+	case b == 0x1b:
+		seq, err := readEscapeSequence(q.reader)
+		if err != nil {
+			return "", err
+		}
+		return "\x1b" + seq, nil
+	case b < utf8.RuneSelf:
+		return string(rune(b)), nil
+	}
+	if err := q.reader.UnreadByte(); err != nil {
+		return "", err
+	}
+	r, _, err := q.reader.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	return string(r), nil
+}
+
+// runAction runs an editor action. It reports whether editing has finished
+// along with the resulting input.
+func (q *prompt) runAction(ctx context.Context, e *editor, action Action) (bool, string, error) {
+	switch action {
+	case ActionAcceptLine:
+		if !q.acceptMask(e) || q.live != nil && !q.live.accept() || !q.echoTransformed(e) {
+			fmt.Fprint(q.writer, "\a")
+			return false, "", nil
+		}
+		e.clearHint()
+		if e.multiline {
+			e.cursor = len(e.line)
+			e.refresh()
+		}
+		fmt.Fprint(q.writer, "\r\n")
+		return true, string(e.line), nil
+	case ActionInterrupt:
+		e.clearHint()
+		return true, "", handleInterrupt(q.writer)
+	case ActionDeleteCharOrEOF:
+		if len(e.line) == 0 {
+			input, err := q.eofValue("")
+			return true, input, err
+		}
+		e.line = deleteGrapheme(e.line, e.cursor)
+	case ActionBracketedPaste:
+		if err := q.paste(e); err != nil {
+			return true, "", err
+		}
+	case ActionEditCommandLine:
+		if err := q.editCommandLine(ctx, e); err != nil {
+			return true, "", err
+		}
+	case ActionQuotedInsert:
+		b, err := q.reader.ReadByte()
+		if err != nil {
+			return true, "", err
+		}
+		r := rune(b)
+		if b >= utf8.RuneSelf {
 			if err := q.reader.UnreadByte(); err != nil {
-				return "", err
+				return true, "", err
 			}
-			r, _, err := q.reader.ReadRune()
-			if err != nil {
-				if errors.Is(err, io.EOF) {
-					return q.eofValue(string(line))
-				}
-				return "", err
+			if r, _, err = q.reader.ReadRune(); err != nil {
+				return true, "", err
 			}
-			if unicode.IsControl(r) {
-				continue
-			}
-			line = append(line[:cursor], append([]rune{r}, line[cursor:]...)...)
-			cursor++
 		}
+		e.insert(r)
+	default:
+		if fn, ok := editorActions[action]; ok {
+			fn(e)
+		}
+	}
+	return false, "", nil
+}
 
-		redrawTerminalLine(q.writer, line, oldLen, oldCursor, cursor, inputOffset, getTerminalWidth(q.fd))
+// paste reads a bracketed paste and inserts it literally.
+func (q *prompt) paste(e *editor) error {
+	text, truncated, err := readPaste(q.reader, q.pasteLimit)
+	if err != nil {
+		return err
 	}
+	runes, ok := cleanPaste(text, q.pastePolicy, e.multiline)
+	if !ok || truncated {
+		fmt.Fprint(q.writer, "\a")
+	}
+	if ok {
+		e.insert(runes...)
+	}
+	return nil
 }
 
 func handleInterrupt(w io.Writer) error {
@@ -248,11 +494,11 @@ func (q *prompt) eofValue(input string) (string, error) {
 	if input != "" {
 		return input, nil
 	}
-	if q.defaultTo != "" {
-		return q.defaultTo, nil
+	if defaultTo := q.defaultValue(); defaultTo != "" {
+		return defaultTo, nil
 	}
 	if !q.optional {
-		return "", ErrRequired
+		return "", ErrEOF
 	}
 	return "", nil
 }
@@ -268,33 +514,47 @@ func getTerminalWidth(fd int) int {
 	return width
 }
 
-func redrawTerminalLine(w io.Writer, line []rune, oldLen, oldCursor, cursor, inputOffset, terminalWidth int) {
+// redrawTerminalLine rewrites the input line followed by the dimmed hint,
+// if any. The old width, old cursor and cursor are measured in terminal
+// columns rather than runes, and the old width includes any hint that was
+// shown.
+func redrawTerminalLine(w io.Writer, line, hint []rune, oldWidth, oldCursor, cursor, inputOffset, terminalWidth int) {
 	if terminalWidth <= 0 {
-		redrawTerminalLineLegacy(w, line, oldCursor, cursor)
+		redrawTerminalLineLegacy(w, line, hint, oldCursor, cursor)
 		return
 	}
 	inputCol := inputOffset % terminalWidth
 	moveVisualCursor(w, inputCol, terminalWidth, oldCursor, 0)
-	fmt.Fprint(w, string(line))
-	printedLen := len(line)
-	if oldLen > len(line) {
-		fmt.Fprint(w, strings.Repeat(" ", oldLen-len(line)))
-		printedLen = oldLen
+	printedWidth := printLine(w, line, hint)
+	if oldWidth > printedWidth {
+		fmt.Fprint(w, strings.Repeat(" ", oldWidth-printedWidth))
+		printedWidth = oldWidth
 	}
-	moveRenderedCursorToLogical(w, inputCol, terminalWidth, printedLen, cursor)
+	moveRenderedCursorToLogical(w, inputCol, terminalWidth, printedWidth, cursor)
 }
 
-func redrawTerminalLineLegacy(w io.Writer, line []rune, oldCursor, cursor int) {
+func redrawTerminalLineLegacy(w io.Writer, line, hint []rune, oldCursor, cursor int) {
 	if oldCursor > 0 {
 		fmt.Fprintf(w, "\x1b[%dD", oldCursor)
 	}
-	fmt.Fprint(w, string(line))
+	printedWidth := printLine(w, line, hint)
 	fmt.Fprint(w, "\x1b[K")
-	if back := len(line) - cursor; back > 0 {
+	if back := printedWidth - cursor; back > 0 {
 		fmt.Fprintf(w, "\x1b[%dD", back)
 	}
 }
 
+// printLine prints the line and the dimmed hint after it, returning the
+// number of columns printed.
+func printLine(w io.Writer, line, hint []rune) int {
+	fmt.Fprint(w, displayString(line))
+	if len(hint) == 0 {
+		return stringWidth(line)
+	}
+	fmt.Fprint(w, hintStart, displayString(hint), hintEnd)
+	return stringWidth(line) + stringWidth(hint)
+}
+
 func moveVisualCursor(w io.Writer, inputCol, width, fromIndex, toIndex int) {
 	if fromIndex == toIndex {
 		return
@@ -340,6 +600,23 @@ func renderedPosition(inputCol, index, width int) (int, int) {
 }
 
 func readEscapeSequence(r *bufio.Reader) (string, error) {
+	// Alt+key sends a single key after the escape.
+	if next, err := r.Peek(1); err == nil && next[0] != '[' && next[0] != 'O' {
+		if next[0] >= utf8.RuneSelf {
+			_, size, err := r.ReadRune()
+			if err != nil {
+				return "", err
+			}
+			if err := r.UnreadRune(); err != nil {
+				return "", err
+			}
+			seq := make([]byte, size)
+			_, err = io.ReadFull(r, seq)
+			return string(seq), err
+		}
+		b, err := r.ReadByte()
+		return string([]byte{b}), err
+	}
 	seq := make([]byte, 0, 16)
 	for i := 0; i < 16; i++ {
 		b, err := r.ReadByte()
@@ -364,62 +641,126 @@ func isEscapeSequenceTerminator(b byte) bool {
 	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z')
 }
 
+// applyEscapeSequence applies the action bound to an escape sequence in the
+// default keymap.
 func applyEscapeSequence(seq string, line []rune, cursor int) ([]rune, int) {
-	switch seq {
-	case "[D", "OD":
-		if cursor > 0 {
-			cursor--
-		}
-	case "[C", "OC":
-		if cursor < len(line) {
-			cursor++
-		}
-	case "[H", "[1~", "[7~", "OH":
-		cursor = 0
-	case "[F", "[4~", "[8~", "OF":
-		cursor = len(line)
-	case "[3~":
-		if cursor < len(line) {
-			line = append(line[:cursor], line[cursor+1:]...)
+	e := &editor{line: line, cursor: cursor, kills: NewKillRing(0)}
+	if action, ok := defaultKeymap.Lookup("\x1b" + seq); ok {
+		if fn, ok := editorActions[action]; ok {
+			fn(e)
 		}
-	case "b", "B", "[1;5D", "[5D":
-		cursor = moveCursorWordLeft(line, cursor)
-	case "f", "F", "[1;5C", "[5C":
-		cursor = moveCursorWordRight(line, cursor)
-	case "\x7f", "\x08", "[3;3~", "[8;3u", "[127;3u":
-		line, cursor = backwardKillWord(line, cursor)
 	}
-	return line, cursor
+	return e.line, e.cursor
 }
 
 func moveCursorWordLeft(line []rune, cursor int) int {
-	for cursor > 0 && unicode.IsSpace(line[cursor-1]) {
-		cursor--
+	for cursor > 0 && unicode.IsSpace(line[prevGrapheme(line, cursor)]) {
+		cursor = prevGrapheme(line, cursor)
 	}
-	for cursor > 0 && !unicode.IsSpace(line[cursor-1]) {
-		cursor--
+	for cursor > 0 && !unicode.IsSpace(line[prevGrapheme(line, cursor)]) {
+		cursor = prevGrapheme(line, cursor)
 	}
 	return cursor
 }
 
 func moveCursorWordRight(line []rune, cursor int) int {
 	for cursor < len(line) && unicode.IsSpace(line[cursor]) {
-		cursor++
+		cursor = nextGrapheme(line, cursor)
 	}
 	for cursor < len(line) && !unicode.IsSpace(line[cursor]) {
-		cursor++
+		cursor = nextGrapheme(line, cursor)
 	}
 	return cursor
 }
 
 func backwardKillWord(line []rune, cursor int) ([]rune, int) {
-	start := cursor
-	for start > 0 && unicode.IsSpace(line[start-1]) {
-		start--
+	start := moveCursorWordLeft(line, cursor)
+	return append(line[:start], line[cursor:]...), start
+}
+
+// transposeChars swaps the clusters on either side of the cursor, or the
+// last two clusters at the end of the line, and moves past them.
+func transposeChars(line []rune, cursor int) ([]rune, int) {
+	if cursor == 0 || len(line) == 0 {
+		return line, cursor
+	}
+	if cursor == len(line) {
+		cursor = prevGrapheme(line, cursor)
+	}
+	start := prevGrapheme(line, cursor)
+	end := nextGrapheme(line, cursor)
+	if start == cursor {
+		return line, cursor
+	}
+	swapped := make([]rune, 0, len(line))
+	swapped = append(swapped, line[:start]...)
+	swapped = append(swapped, line[cursor:end]...)
+	swapped = append(swapped, line[start:cursor]...)
+	return append(swapped, line[end:]...), end
+}
+
+// transposeWords swaps the word before the cursor with the word at or after
+// it and moves past them.
+func transposeWords(line []rune, cursor int) ([]rune, int) {
+	end2 := moveCursorWordRight(line, cursor)
+	start2 := moveCursorWordLeft(line, end2)
+	start1 := moveCursorWordLeft(line, start2)
+	if start1 == start2 {
+		return line, cursor
+	}
+	end1 := start1
+	for end1 < start2 && !unicode.IsSpace(line[end1]) {
+		end1 = nextGrapheme(line, end1)
+	}
+	swapped := make([]rune, 0, len(line))
+	swapped = append(swapped, line[:start1]...)
+	swapped = append(swapped, line[start2:end2]...)
+	swapped = append(swapped, line[end1:start2]...)
+	swapped = append(swapped, line[start1:end1]...)
+	return append(swapped, line[end2:]...), end2
+}
+
+// mapWord maps the runes from the cursor to the end of the word and returns
+// the new cursor after the word.
+func mapWord(line []rune, cursor int, mapping func(rune) rune) int {
+	end := moveCursorWordRight(line, cursor)
+	for i := cursor; i < end; i++ {
+		line[i] = mapping(line[i])
+	}
+	return end
+}
+
+// capitalizeWord uppercases the first letter of the word at or after the
+// cursor and lowercases the rest.
+func capitalizeWord(line []rune, cursor int) int {
+	end := moveCursorWordRight(line, cursor)
+	first := true
+	for i := cursor; i < end; i++ {
+		switch {
+		case first && unicode.IsLetter(line[i]):
+			line[i] = unicode.ToTitle(line[i])
+			first = false
+		case !first:
+			line[i] = unicode.ToLower(line[i])
+		}
+	}
+	return end
+}
+
+// deleteGrapheme deletes the cluster under the cursor.
+func deleteGrapheme(line []rune, cursor int) []rune {
+	if cursor >= len(line) {
+		return line
 	}
-	for start > 0 && !unicode.IsSpace(line[start-1]) {
-		start--
+	return append(line[:cursor], line[nextGrapheme(line, cursor):]...)
+}
+
+// backwardDeleteGrapheme deletes the cluster before the cursor.
+func backwardDeleteGrapheme(line []rune, cursor int) ([]rune, int) {
+	if cursor <= 0 {
+		return line, cursor
 	}
+	start := prevGrapheme(line, cursor)
 	return append(line[:start], line[cursor:]...), start
 }
 
@@ -431,20 +772,33 @@ func backwardKillLine(line []rune, cursor int) ([]rune, int) {
 // otherwise read the line from the scanner.
 func (q *prompt) scanPassword(inputCh chan<- string, errorCh chan<- error) {
 	if q.isTerminal() {
-		pass, err := term.ReadPassword(q.fd)
+		pass, err := q.terminalPassword(term.ReadPassword(q.fd))
 		if err != nil {
 			errorCh <- err
 			return
 		}
-		inputCh <- string(pass)
+		inputCh <- pass
 		return
 	}
 
 	q.scanLine(inputCh, errorCh)
 }
 
+// terminalPassword returns the password read from a terminal. Ctrl+D on an
+// empty line gives io.EOF, which ends the input like it does for scanLine.
+func (q *prompt) terminalPassword(pass []byte, err error) (string, error) {
+	if errors.Is(err, io.EOF) {
+		q.eof = true
+		return q.eofValue(string(pass))
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(pass), nil
+}
+
 // Reads the input from the reader.
-func (q *prompt) readInput(ctx context.Context, inputOffset int) (string, error) {
+func (q *prompt) readInput(ctx context.Context, promptText string) (string, error) {
 	// Check if the context has already been cancelled.
 	if ctx.Err() != nil {
 		return "", ctx.Err()
@@ -452,14 +806,18 @@ func (q *prompt) readInput(ctx context.Context, inputOffset int) (string, error)
 
 	// Terminal input is handled synchronously to guarantee raw mode cleanup.
 	if q.isTerminal() {
-		return q.readTerminalLine(inputOffset)
+		return q.readTerminalLine(ctx, promptText)
 	}
 
 	inputCh := make(chan string)
 	errorCh := make(chan error)
 
 	// Scan for the input in a goroutine, so we can listen for cancellations.
-	go q.scanLine(inputCh, errorCh)
+	if q.multiline {
+		go q.scanText(inputCh, errorCh)
+	} else {
+		go q.scanLine(inputCh, errorCh)
+	}
 
 	// Wait for input, an error or the context to be cancelled.
 	select {
@@ -514,39 +872,79 @@ func (q *prompt) readPassword(ctx context.Context) (string, error) {
 
 // Ask asks a question and returns the input.
 func (q *prompt) Ask(ctx context.Context, prompt string) (string, error) {
+	attempts := 0
+
 	// Write out the formatted prompt.
 retry:
 	promptText := prompt + " "
 	fmt.Fprint(q.writer, promptText)
 
 	// Read the input.
-	input, err := q.readInput(ctx, utf8.RuneCountInString(promptText))
+	input, err := q.readInput(ctx, promptText)
 	if err != nil {
 		return "", err
 	}
+	checked := q.live.passed(input)
+
+	// Normalize the input, unless that was already done while editing.
+	if !q.transformed {
+		if input, err = q.transform(input); err != nil {
+			fmt.Fprintln(q.writer, err)
+			if err := q.failAttempt(prompt, &attempts, err); err != nil {
+				return "", err
+			}
+			goto retry
+		}
+	}
+
+	// Fit the input to the mask.
+	if q.mask != nil && input != "" {
+		if input, err = q.maskValue(input); err != nil {
+			fmt.Fprintln(q.writer, err)
+			if err := q.failAttempt(prompt, &attempts, err); err != nil {
+				return "", err
+			}
+			goto retry
+		}
+	}
 
 	// If the input is empty, and there is a default, use it otherwise ask again.
 	if input == "" {
-		if q.defaultTo != "" {
-			return q.defaultTo, nil
+		if defaultTo := q.defaultValue(); defaultTo != "" {
+			return defaultTo, nil
 		} else if !q.optional {
+			if err := q.failAttempt(prompt, &attempts, ErrRequired); err != nil {
+				return "", err
+			}
 			goto retry
 		}
 	}
 
-	// If any checks fail, print the error and ask again.
-	for _, check := range q.checks {
-		if err := check(input); err != nil {
+	// If any checks fail, print the error and ask again. Live checks have
+	// already run against terminal input.
+	if !checked {
+		if err := q.runChecksWithSpinner(ctx, input); err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
 			fmt.Fprintln(q.writer, err)
+			if err := q.failAttempt(prompt, &attempts, err); err != nil {
+				return "", err
+			}
 			goto retry
 		}
 	}
 
+	if q.history != nil {
+		q.history.Add(input)
+	}
 	return input, nil
 }
 
 // Password asks for a password and returns the input.
 func (q *prompt) Password(ctx context.Context, prompt string) (string, error) {
+	attempts := 0
+
 	// Write out the formatted prompt.
 retry:
 	fmt.Fprint(q.writer, prompt, " ")
@@ -558,21 +956,35 @@ retry:
 	}
 	// Print a newline after the password.
 	fmt.Fprintln(q.writer)
+	if pass, err = q.transform(pass); err != nil {
+		fmt.Fprintln(q.writer, err)
+		if err := q.failAttempt(prompt, &attempts, err); err != nil {
+			return "", err
+		}
+		goto retry
+	}
 
 	if pass == "" {
 		if q.defaultTo != "" {
 			return q.defaultTo, nil
 		} else if !q.optional {
+			if err := q.failAttempt(prompt, &attempts, ErrRequired); err != nil {
+				return "", err
+			}
 			goto retry
 		}
 	}
 
 	// If any checks fail, print the error and ask again.
-	for _, check := range q.checks {
-		if err := check(pass); err != nil {
-			fmt.Fprintln(q.writer, err)
-			goto retry
+	if err := q.runChecksWithSpinner(ctx, pass); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		fmt.Fprintln(q.writer, err)
+		if err := q.failAttempt(prompt, &attempts, err); err != nil {
+			return "", err
 		}
+		goto retry
 	}
 
 	return pass, nil
@@ -589,7 +1001,7 @@ func isYes(s string) bool {
 // Confirm asks for a confirmation and returns the input.
 func (q *prompt) Confirm(ctx context.Context, prompt string) (bool, error) {
 	// Add a check to ensure the input is yes or no.
-	q.checks = append(q.checks, func(s string) error {
+	q.checks = append(q.checks, func(_ context.Context, s string) error {
 		switch strings.ToLower(s) {
 		case "y", "yes":
 			return nil
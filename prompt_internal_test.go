@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -161,7 +162,7 @@ func TestRedrawTerminalLineWrappedDelete(t *testing.T) {
 	is := is.New(t)
 	writer := new(bytes.Buffer)
 
-	redrawTerminalLine(writer, []rune("abcde"), 7, 7, 5, 2, 4)
+	redrawTerminalLine(writer, []rune("abcde"), nil, 7, 7, 5, 2, 4)
 
 	is.Equal(writer.String(), "\x1b[2A\r\x1b[2Cabcde  \x1b[1A\r\x1b[3C")
 }
@@ -170,7 +171,7 @@ func TestRedrawTerminalLineLegacyFallback(t *testing.T) {
 	is := is.New(t)
 	writer := new(bytes.Buffer)
 
-	redrawTerminalLine(writer, []rune("hello"), 3, 2, 5, 0, 0)
+	redrawTerminalLine(writer, []rune("hello"), nil, 3, 2, 5, 0, 0)
 
 	is.Equal(writer.String(), "\x1b[2Dhello\x1b[K")
 }
@@ -199,7 +200,132 @@ func TestRedrawTerminalLineWrapBoundaryCursorAdvance(t *testing.T) {
 	is := is.New(t)
 	writer := new(bytes.Buffer)
 
-	redrawTerminalLine(writer, []rune("abcd"), 3, 3, 4, 0, 4)
+	redrawTerminalLine(writer, []rune("abcd"), nil, 3, 3, 4, 0, 4)
 
 	is.Equal(writer.String(), "\rabcd\x1b[1B\r")
 }
+
+func TestApplyEscapeSequenceKillWord(t *testing.T) {
+	is := is.New(t)
+	line := []rune("hello brave world")
+
+	line, cursor := applyEscapeSequence("d", line, 5)
+	is.Equal(string(line), "hello world")
+	is.Equal(cursor, 5)
+}
+
+func TestApplyEscapeSequenceTransposeWords(t *testing.T) {
+	is := is.New(t)
+
+	line, cursor := applyEscapeSequence("t", []rune("hello brave world"), 7)
+	is.Equal(string(line), "brave hello world")
+	is.Equal(cursor, 11)
+
+	line, cursor = applyEscapeSequence("t", []rune("hello world"), 11)
+	is.Equal(string(line), "world hello")
+	is.Equal(cursor, 11)
+
+	line, cursor = applyEscapeSequence("t", []rune("hello"), 2)
+	is.Equal(string(line), "hello")
+	is.Equal(cursor, 2)
+}
+
+func TestApplyEscapeSequenceCaseWords(t *testing.T) {
+	is := is.New(t)
+
+	line, cursor := applyEscapeSequence("u", []rune("hello world"), 0)
+	is.Equal(string(line), "HELLO world")
+	is.Equal(cursor, 5)
+
+	line, cursor = applyEscapeSequence("l", []rune("HELLO WORLD"), 5)
+	is.Equal(string(line), "HELLO world")
+	is.Equal(cursor, 11)
+
+	line, cursor = applyEscapeSequence("c", []rune("hELLO wORLD"), 5)
+	is.Equal(string(line), "hELLO World")
+	is.Equal(cursor, 11)
+}
+
+func TestTransposeChars(t *testing.T) {
+	is := is.New(t)
+
+	line, cursor := transposeChars([]rune("abcd"), 2)
+	is.Equal(string(line), "acbd")
+	is.Equal(cursor, 3)
+
+	line, cursor = transposeChars([]rune("abcd"), 4)
+	is.Equal(string(line), "abdc")
+	is.Equal(cursor, 4)
+
+	line, cursor = transposeChars([]rune("abcd"), 0)
+	is.Equal(string(line), "abcd")
+	is.Equal(cursor, 0)
+
+	line, cursor = transposeChars([]rune("ae\u0301"), 3)
+	is.Equal(string(line), "e\u0301a")
+	is.Equal(cursor, 3)
+}
+
+func TestYankLastArg(t *testing.T) {
+	is := is.New(t)
+	history := NewHistory(0)
+	history.Add("cp notes.txt /tmp")
+	history.Add("ls -la ~/src")
+	e := newEditor(new(bytes.Buffer), -1, "? ")
+	e.history = history
+
+	typeKeys(t, e, "cd \x1b.")
+	is.Equal(string(e.line), "cd ~/src")
+
+	typeKeys(t, e, "\x1b.")
+	is.Equal(string(e.line), "cd /tmp")
+
+	// Running out of history leaves the last argument in place.
+	typeKeys(t, e, "\x1b.")
+	is.Equal(string(e.line), "cd /tmp")
+}
+
+func TestQuotedInsert(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(new(bytes.Buffer), -1, "? ")
+
+	typeKeys(t, e, "a\x16\x01b\x16é")
+	is.Equal(string(e.line), "a\x01bé")
+	is.Equal(displayString(e.line), "a^Abé")
+	is.Equal(stringWidth(e.line), 5)
+}
+
+func TestReadEscapeSequenceAltKey(t *testing.T) {
+	is := is.New(t)
+	r := bufio.NewReader(strings.NewReader(".X"))
+
+	seq, err := readEscapeSequence(r)
+	is.NoErr(err)
+	is.Equal(seq, ".")
+
+	b, err := r.ReadByte()
+	is.NoErr(err)
+	is.Equal(string([]byte{b}), "X")
+}
+
+func TestTerminalPasswordEOF(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt()
+	_, err := q.terminalPassword(nil, io.EOF)
+	is.True(errors.Is(err, ErrEOF))
+	is.True(q.eof)
+
+	q = newPrompt(WithDefault("secret"))
+	pass, err := q.terminalPassword(nil, io.EOF)
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+
+	q = newPrompt(WithOptional(true))
+	pass, err = q.terminalPassword(nil, io.EOF)
+	is.NoErr(err)
+	is.Equal(pass, "")
+
+	pass, err = newPrompt().terminalPassword([]byte("hunter2"), nil)
+	is.NoErr(err)
+	is.Equal(pass, "hunter2")
+}
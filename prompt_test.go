@@ -69,7 +69,7 @@ func TestAskWithReaderOptionIsPerCall(t *testing.T) {
 	is.Equal(name, "Mark")
 }
 
-func TestAskErrRequired(t *testing.T) {
+func TestAskErrEOF(t *testing.T) {
 	is := is.New(t)
 	ctx := context.Background()
 	reader := bytes.NewBufferString("Mark\n27\n")
@@ -94,10 +94,43 @@ func TestAskErrRequired(t *testing.T) {
 		withReader,
 		withWriter,
 	)
-	is.True(errors.Is(err, prompt.ErrRequired))
+	is.True(errors.Is(err, prompt.ErrEOF))
+	is.True(errors.Is(err, io.EOF))
 	is.Equal(height, "")
 }
 
+func TestAskEOFWithoutNewline(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	age, err := prompt.Ask(ctx, "What is your age?",
+		prompt.WithReader(bytes.NewBufferString("36")),
+		prompt.WithWriter(io.Discard),
+	)
+	is.NoErr(err)
+	is.Equal(age, "36")
+}
+
+func TestAskEOFStopsRetrying(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	errTooShort := errors.New("too short")
+
+	_, err := prompt.Ask(ctx, "What is your name?",
+		prompt.WithReader(bytes.NewBufferString("a\nb")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithOptional(true),
+		prompt.WithCheck(func(s string) error {
+			if len(s) < 2 {
+				return errTooShort
+			}
+			return nil
+		}),
+	)
+	is.True(errors.Is(err, prompt.ErrEOF))
+	is.True(errors.Is(err, errTooShort))
+}
+
 func TestAskOptional(t *testing.T) {
 	is := is.New(t)
 	ctx := context.Background()
@@ -366,3 +399,24 @@ func TestConfirmCancel(t *testing.T) {
 	)
 	is.True(errors.Is(err, context.Canceled))
 }
+
+func TestAskInitialValue(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n/usr/local/sbin\n")
+	withReader := prompt.WithReader(reader)
+	withWriter := prompt.WithWriter(io.Discard)
+	withInitialValue := prompt.WithInitialValue("/usr/local/bin")
+
+	dir, err := prompt.Ask(ctx, "Install to?", withReader, withWriter, withInitialValue)
+	is.NoErr(err)
+	is.Equal(dir, "/usr/local/bin")
+
+	dir, err = prompt.Ask(ctx, "Install to?", withReader, withWriter, withInitialValue)
+	is.NoErr(err)
+	is.Equal(dir, "/usr/local/sbin")
+
+	dir, err = prompt.Ask(ctx, "Install to?", withReader, withWriter, withInitialValue)
+	is.NoErr(err)
+	is.Equal(dir, "/usr/local/bin")
+}
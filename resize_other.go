@@ -0,0 +1,11 @@
+//go:build windows || plan9 || js || wasip1
+
+package prompt
+
+import "os"
+
+// notifyResize is a no-op on platforms without SIGWINCH. The width is still
+// checked before every redraw.
+func notifyResize(ch chan<- os.Signal) bool {
+	return false
+}
@@ -0,0 +1,15 @@
+//go:build !windows && !plan9 && !js && !wasip1
+
+package prompt
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize relays terminal resize signals to ch.
+func notifyResize(ch chan<- os.Signal) bool {
+	signal.Notify(ch, syscall.SIGWINCH)
+	return true
+}
@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"context"
+	"math"
+	"strings"
+)
+
+// WithTicks labels values below a slider's bar. By default the ends of the
+// range are labelled.
+func WithTicks(values ...float64) Option {
+	return func(q *prompt) {
+		q.ticks = values
+	}
+}
+
+// Slider asks for a number between min and max.
+func Slider(ctx context.Context, prompt string, min, max float64, options ...Option) (float64, error) {
+	q := newPrompt(options...)
+	return q.Slider(ctx, prompt, min, max)
+}
+
+// Slider asks for a number between min and max. In a terminal, a bar with
+// tick labels is shown below the input, and the arrow keys and Page Up/Down
+// move the value by WithStep, which defaults to 1. Otherwise the number is
+// typed like Float.
+func (q *prompt) Slider(ctx context.Context, prompt string, min, max float64) (float64, error) {
+	q.minNumber, q.maxNumber = min, max
+	q.slider = true
+	q.keymap = sliderKeymap(q.keymap)
+	if q.initialValue == "" && q.isTerminal() {
+		// Start the bar at the default or the low end.
+		n := q.newNumberInput(false)
+		start, err := n.parse(q.defaultTo)
+		if err != nil {
+			start = n.min
+		}
+		q.initialValue = n.format(start)
+	}
+	return q.Float(ctx, prompt)
+}
+
+// sliderKeymap adapts a keymap for moving a slider with Left and Right, in
+// addition to the keys that step numbers.
+func sliderKeymap(base *Keymap) *Keymap {
+	k := base.clone()
+	k.bindAll(ActionDecrement, "\x1b[D", "\x1bOD")
+	k.bindAll(ActionIncrement, "\x1b[C", "\x1bOC")
+	return k
+}
+
+// sliderWidth is the widest the bar is drawn.
+const sliderWidth = 40
+
+// bar draws the slider for the number in line with its tick labels below.
+// Out of range numbers are drawn at the nearest end. Nothing is drawn if
+// the terminal is too narrow.
+func (n *numberInput) bar(line string, width int) []string {
+	cols := sliderWidth
+	if width > 0 {
+		cols = min(cols, width-1)
+	}
+	if cols < 10 {
+		return nil
+	}
+	v, err := n.value(line)
+	if err != nil {
+		v = n.min
+	}
+	pos := n.position(n.limit(v), cols)
+	bar := strings.Repeat("━", pos) + "●" + hintStart + strings.Repeat("─", cols-pos-1) + hintEnd
+	return []string{bar, n.tickLabels(cols)}
+}
+
+// position returns the column of v on a bar cols wide.
+func (n *numberInput) position(v float64, cols int) int {
+	if n.max <= n.min {
+		return 0
+	}
+	return int(math.Round((v - n.min) / (n.max - n.min) * float64(cols-1)))
+}
+
+// tickLabels places a label under each tick, centered where possible.
+// Labels that would overlap the one before are left out.
+func (n *numberInput) tickLabels(cols int) string {
+	ticks := n.ticks
+	if len(ticks) == 0 {
+		ticks = []float64{n.min, n.max}
+	}
+	row := []rune(strings.Repeat(" ", cols))
+	end := -1 // column after the last label
+	for _, tick := range ticks {
+		if tick < n.min || tick > n.max {
+			continue
+		}
+		label := []rune(n.format(tick))
+		if len(label) > cols {
+			continue
+		}
+		start := n.position(tick, cols) - len(label)/2
+		start = max(0, min(start, cols-len(label)))
+		if start <= end {
+			continue
+		}
+		copy(row[start:], label)
+		end = start + len(label)
+	}
+	return strings.TrimRight(string(row), " ")
+}
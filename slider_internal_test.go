@@ -0,0 +1,53 @@
+package prompt
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSliderBar(t *testing.T) {
+	is := is.New(t)
+	n := newPrompt(WithNumberRange(0, 10), WithTicks(0, 5, 10)).newNumberInput(false)
+	is.Equal(n.bar("3", 12), []string{
+		"━━━●\x1b[90m───────\x1b[39m",
+		"0    5   10",
+	})
+
+	// Out of range and invalid numbers are drawn at the ends.
+	is.Equal(n.bar("99", 12)[0], "━━━━━━━━━━●\x1b[90m\x1b[39m")
+	is.Equal(n.bar("", 12)[0], "●\x1b[90m──────────\x1b[39m")
+
+	// The bar is at most sliderWidth wide.
+	is.Equal(n.position(10, sliderWidth), sliderWidth-1)
+	is.Equal(len([]rune(n.bar("10", 0)[0])), sliderWidth+len(hintStart)+len(hintEnd))
+
+	// Nothing is drawn in a narrow terminal.
+	is.Equal(len(n.bar("3", 10)), 0)
+}
+
+func TestSliderTickLabels(t *testing.T) {
+	is := is.New(t)
+	// Labels that would overlap are left out.
+	n := newPrompt(WithNumberRange(0, 100), WithTicks(0, 5, 100)).newNumberInput(false)
+	is.Equal(n.tickLabels(11), "0       100")
+
+	// By default the ends are labelled, and ticks out of range are ignored.
+	n = newPrompt(WithNumberRange(0.5, 1)).newNumberInput(false)
+	is.Equal(n.tickLabels(11), "0.5       1")
+	n.ticks = []float64{0, 0.75, 2}
+	is.Equal(n.tickLabels(11), "   0.75")
+}
+
+func TestSliderKeys(t *testing.T) {
+	is := is.New(t)
+	e := newEditor(io.Discard, -1, "? ")
+	e.line, e.cursor = []rune("5"), 1
+	e.numberInput = newPrompt(WithNumberRange(0, 10)).newNumberInput(false)
+	keymap := func(q *prompt) { q.keymap = numberKeymap(sliderKeymap(q.keymap)) }
+	typeKeys(t, e, "\x1b[C\x1b[C\x1b[D\x1b[5~", keymap)
+	is.Equal(string(e.line), "10")
+	typeKeys(t, e, "\x1b[6~\x1b[D", keymap)
+	is.Equal(string(e.line), "0")
+}
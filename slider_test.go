@@ -0,0 +1,37 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestSlider(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	percent, err := prompt.Slider(ctx, "Traffic?", 0, 100,
+		prompt.WithReader(bytes.NewBufferString("150\n25.5\n")),
+		prompt.WithWriter(writer),
+	)
+	is.NoErr(err)
+	is.Equal(percent, 25.5)
+	diff.TestString(t, writer.String(), "Traffic? must be between 0 and 100\nTraffic? ")
+}
+
+func TestSliderDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	replicas, err := prompt.Slider(ctx, "Replicas?", 1, 10,
+		prompt.WithReader(bytes.NewBufferString("\n")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithDefault("3"),
+	)
+	is.NoErr(err)
+	is.Equal(replicas, 3.0)
+}
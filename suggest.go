@@ -0,0 +1,69 @@
+package prompt
+
+import "strings"
+
+// WithSuggestions shows the most likely completion of the line as dimmed
+// text after the cursor. Right arrow or Ctrl+F at the end of the line accepts
+// it and Alt+F accepts the next word. Suggestions come from suggest, which
+// returns a whole line starting with its input, then from the history set
+// with WithHistory. suggest may be nil to only suggest from history.
+func WithSuggestions(suggest func(line string) string) Option {
+	return func(q *prompt) {
+		q.suggestions = true
+		q.suggester = suggest
+	}
+}
+
+// suggestion returns the best suggestion for line.
+func (q *prompt) suggestion(line string) string {
+	if q.suggester != nil {
+		if suggestion := q.suggester(line); suggestion != "" {
+			return suggestion
+		}
+	}
+	if q.history != nil {
+		return q.history.suggest(line)
+	}
+	return ""
+}
+
+// suggest returns the most recent entry that starts with prefix and is
+// longer than it.
+func (h *History) suggest(prefix string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if len(h.entries[i]) > len(prefix) && strings.HasPrefix(h.entries[i], prefix) {
+			return h.entries[i]
+		}
+	}
+	return ""
+}
+
+// suggestion returns the rest of the suggested line to show after the cursor.
+// Suggestions are only shown while the cursor is at the end of the line.
+func (e *editor) suggestion() []rune {
+	if e.suggest == nil || e.multiline || len(e.line) == 0 || e.cursor != len(e.line) {
+		return nil
+	}
+	line := string(e.line)
+	suggestion := e.suggest(line)
+	if len(suggestion) <= len(line) || !strings.HasPrefix(suggestion, line) {
+		return nil
+	}
+	return []rune(suggestion[len(line):])
+}
+
+// acceptSuggestion inserts the suggestion, or only its next word, and
+// reports whether there was one to accept.
+func (e *editor) acceptSuggestion(word bool) bool {
+	rest := e.suggestion()
+	if len(rest) == 0 {
+		return false
+	}
+	if word {
+		rest = rest[:moveCursorWordRight(rest, 0)]
+	}
+	e.insert(rest...)
+	return true
+}
@@ -0,0 +1,81 @@
+package prompt
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func newSuggestEditor(w io.Writer, entries ...string) *editor {
+	history := NewHistory(0)
+	for _, entry := range entries {
+		history.Add(entry)
+	}
+	q := newPrompt(WithHistory(history), WithSuggestions(nil))
+	e := newEditor(w, -1, "? ")
+	e.width = func() int { return 20 }
+	e.layout.width = 20
+	e.suggest = q.suggestion
+	return e
+}
+
+func TestHistorySuggest(t *testing.T) {
+	is := is.New(t)
+	history := NewHistory(0)
+	history.Add("git status")
+	history.Add("git stash")
+	history.Add("go test")
+	is.Equal(history.suggest("git st"), "git stash")
+	is.Equal(history.suggest("git stat"), "git status")
+	is.Equal(history.suggest("go test"), "")
+	is.Equal(history.suggest("ls"), "")
+}
+
+func TestSuggester(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithSuggestions(func(line string) string {
+		if strings.HasPrefix("deploy", line) {
+			return "deploy"
+		}
+		return ""
+	}))
+	is.Equal(q.suggestion("dep"), "deploy")
+	is.Equal(q.suggestion("git"), "")
+}
+
+func TestSuggestionRender(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newSuggestEditor(writer, "git status")
+
+	e.line, e.cursor = []rune("git s"), 5
+	e.refresh()
+	is.Equal(writer.String(), "git s\x1b[90mtatus\x1b[39m\r\x1b[7C")
+	is.Equal(e.layout.lineWidth, 10)
+
+	// Moving the cursor off the end clears the suggestion.
+	writer.Reset()
+	e.cursor = 4
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[2Cgit s     \r\x1b[6C")
+	is.Equal(e.layout.lineWidth, 5)
+}
+
+func TestSuggestionAccept(t *testing.T) {
+	is := is.New(t)
+	e := newSuggestEditor(io.Discard, "git commit --amend")
+
+	typeKeys(t, e, "git c\x1bf")
+	is.Equal(string(e.line), "git commit")
+	typeKeys(t, e, "\x1b[C")
+	is.Equal(string(e.line), "git commit --amend")
+	is.Equal(e.cursor, len(e.line))
+
+	// Away from the end of the line, the keys just move the cursor.
+	typeKeys(t, e, "\x15git c\x02\x06")
+	is.Equal(string(e.line), "git c")
+	is.Equal(e.cursor, 5)
+}
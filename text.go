@@ -0,0 +1,206 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Keys that can submit a multi-line text prompt.
+const (
+	KeyCtrlD    = "\x04"
+	KeyAltEnter = "\x1b\r"
+)
+
+// WithSubmitKey sets the key sequence that submits a multi-line text
+// prompt. It defaults to KeyCtrlD.
+func WithSubmitKey(keys string) Option {
+	return func(q *prompt) {
+		if keys == "" {
+			return
+		}
+		q.submitKey = keys
+	}
+}
+
+// WithTerminator ends multi-line input read from a non-terminal when a line
+// matches terminator exactly. Input otherwise ends at EOF.
+func WithTerminator(terminator string) Option {
+	return func(q *prompt) {
+		q.terminator = terminator
+	}
+}
+
+// Text asks a question and returns multi-line input.
+func Text(ctx context.Context, prompt string, options ...Option) (string, error) {
+	q := newPrompt(options...)
+	return q.Text(ctx, prompt)
+}
+
+// Text asks a question and returns multi-line input. In a terminal, Enter
+// inserts a newline and the submit key finishes editing.
+func (q *prompt) Text(ctx context.Context, prompt string) (string, error) {
+	q.multiline = true
+	q.keymap = textKeymap(q.keymap, q.submitKey)
+	return q.Ask(ctx, prompt)
+}
+
+// textKeymap adapts a keymap for multi-line editing.
+func textKeymap(base *Keymap, submit string) *Keymap {
+	k := base.clone()
+	k.bindAll(ActionInsertNewline, "\r", "\n")
+	k.bindAll(ActionPreviousLine, "\x1b[A", "\x1bOA", "\x10")
+	k.bindAll(ActionNextLine, "\x1b[B", "\x1bOB", "\x0e")
+	k.bindAll(ActionAcceptLine, submit)
+	return k
+}
+
+// scanText reads lines until EOF or the terminator line.
+func (q *prompt) scanText(inputCh chan<- string, errorCh chan<- error) {
+	var lines []string
+	for {
+		line, err := q.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			errorCh <- err
+			return
+		}
+		eof := err != nil
+		q.eof = q.eof || eof
+		line = strings.TrimRight(line, "\r\n")
+		if q.terminator != "" && line == q.terminator {
+			break
+		}
+		if !eof || line != "" {
+			lines = append(lines, line)
+		}
+		if eof {
+			if len(lines) == 0 {
+				input, err := q.eofValue("")
+				if err != nil {
+					errorCh <- err
+					return
+				}
+				inputCh <- input
+				return
+			}
+			break
+		}
+	}
+	inputCh <- strings.Join(lines, "\n")
+}
+
+// lineStart returns the index of the start of the logical line containing
+// the cursor.
+func lineStart(line []rune, cursor int) int {
+	for cursor > 0 && line[cursor-1] != '\n' {
+		cursor--
+	}
+	return cursor
+}
+
+// lineEnd returns the index of the end of the logical line containing the
+// cursor.
+func lineEnd(line []rune, cursor int) int {
+	for cursor < len(line) && line[cursor] != '\n' {
+		cursor++
+	}
+	return cursor
+}
+
+// moveLine moves the cursor to the previous or next logical line, keeping
+// the display column where possible.
+func moveLine(line []rune, cursor, delta int) int {
+	start := lineStart(line, cursor)
+	col := stringWidth(line[start:cursor])
+	switch {
+	case delta < 0 && start > 0:
+		start = lineStart(line, start-1)
+	case delta > 0 && lineEnd(line, cursor) < len(line):
+		start = lineEnd(line, cursor) + 1
+	default:
+		return cursor
+	}
+	end := lineEnd(line, start)
+	target := start
+	for target < end {
+		next := nextGrapheme(line, target)
+		if stringWidth(line[start:next]) > col {
+			break
+		}
+		target = next
+	}
+	return target
+}
+
+// textPosition returns the row and column of line[index] when line is
+// printed starting at inputCol, wrapping at width and breaking rows at
+// newlines. A width of zero disables wrapping.
+func textPosition(line []rune, inputCol, width, index int) (int, int) {
+	row, col := 0, inputCol
+	if width > 0 {
+		row, col = col/width, col%width
+	}
+	for i := 0; i < index; {
+		next := nextGrapheme(line, i)
+		if line[i] == '\n' {
+			row, col = row+1, 0
+			i = next
+			continue
+		}
+		w := clusterWidth(line[i:next])
+		if width > 0 && col+w > width {
+			row, col = row+1, 0
+		}
+		col += w
+		if width > 0 && col >= width {
+			row, col = row+1, 0
+		}
+		i = next
+	}
+	return row, col
+}
+
+// renderText repaints the prompt and the whole multi-line input, starting
+// from the row the prompt was printed on.
+func (e *editor) renderText(width int) {
+	if e.layout.cursorRow > 0 {
+		fmt.Fprintf(e.w, "\x1b[%dA", e.layout.cursorRow)
+	}
+	// Erase from the origin, keeping any text before the prompt.
+	fmt.Fprint(e.w, "\r")
+	if e.origin > 0 {
+		fmt.Fprintf(e.w, "\x1b[%dC", e.origin)
+	}
+	fmt.Fprint(e.w, "\x1b[J")
+	fmt.Fprint(e.w, e.prompt)
+	for i, segment := range strings.Split(string(e.line), "\n") {
+		if i > 0 {
+			fmt.Fprint(e.w, "\r\n")
+		}
+		fmt.Fprint(e.w, displayString([]rune(segment)))
+	}
+	printed := e.line
+	if hint := e.hint(); len(hint) > 0 {
+		printed = []rune(displayString(hint))
+		fmt.Fprint(e.w, hintStart, string(printed), hintEnd)
+	}
+	inputCol := e.origin + e.promptWidth
+	endRow, endCol := textPosition(printed, inputCol, width, len(printed))
+	// The terminal holds the cursor in the last column after filling a row.
+	if width > 0 && endCol == 0 && endRow > 0 && (len(printed) == 0 || printed[len(printed)-1] != '\n') {
+		endRow--
+	}
+	cursorRow, cursorCol := textPosition(e.line, inputCol, width, e.cursor)
+	moveCursor(e.w, endRow, cursorRow, cursorCol)
+	e.layout = layout{
+		width:       width,
+		prompt:      e.prompt,
+		promptWidth: e.promptWidth,
+		lineWidth:   stringWidth(e.line),
+		cursor:      stringWidth(e.line[:e.cursor]),
+		cursorRow:   cursorRow,
+	}
+	e.drawStatus(width, cursorRow, cursorCol, endRow)
+}
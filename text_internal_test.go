@@ -0,0 +1,108 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func newTextEditor(prompt string, width int) *editor {
+	e := newEditor(io.Discard, -1, prompt)
+	e.width = func() int { return width }
+	e.layout.width = width
+	e.multiline = true
+	return e
+}
+
+func withTextKeymap() Option {
+	return func(q *prompt) {
+		q.keymap = textKeymap(q.keymap, q.submitKey)
+	}
+}
+
+func TestMoveLine(t *testing.T) {
+	is := is.New(t)
+	line := []rune("hello\nhi\nworld")
+	is.Equal(moveLine(line, 4, 1), 8)   // clamps to the end of "hi"
+	is.Equal(moveLine(line, 8, 1), 11)  // keeps column 2
+	is.Equal(moveLine(line, 11, -1), 8) // back up to "hi"
+	is.Equal(moveLine(line, 2, -1), 2)  // no line above
+	is.Equal(moveLine(line, 12, 1), 12) // no line below
+	is.Equal(moveLine([]rune("世界\nab"), 1, 1), 5)
+}
+
+func TestTextPosition(t *testing.T) {
+	is := is.New(t)
+	line := []rune("abcdef\ngh")
+	row, col := textPosition(line, 2, 0, len(line))
+	is.Equal(row, 1)
+	is.Equal(col, 2)
+	row, col = textPosition(line, 2, 4, 6)
+	is.Equal(row, 2)
+	is.Equal(col, 0)
+	row, col = textPosition(line, 2, 4, len(line))
+	is.Equal(row, 3)
+	is.Equal(col, 2)
+}
+
+func TestTextEditing(t *testing.T) {
+	is := is.New(t)
+	e := newTextEditor("? ", 80)
+
+	typeKeys(t, e, "one\rtwo\x1b[Ax", withTextKeymap())
+	is.Equal(string(e.line), "onex\ntwo")
+	typeKeys(t, e, "\x0e\x01z\x05!", withTextKeymap())
+	is.Equal(string(e.line), "onex\nztwo!")
+	typeKeys(t, e, "\x15", withTextKeymap())
+	is.Equal(string(e.line), "onex\n")
+}
+
+func TestTextSubmit(t *testing.T) {
+	is := is.New(t)
+	q := newPrompt(WithReader(bytes.NewBufferString("\x1b\r")), WithWriter(io.Discard), WithSubmitKey(KeyAltEnter), withTextKeymap())
+	e := newTextEditor("? ", 80)
+	e.line = []rune("a\nb")
+	e.cursor = 1
+	b, err := q.reader.ReadByte()
+	is.NoErr(err)
+	done, line, err := q.editKey(context.Background(), e, b)
+	is.NoErr(err)
+	is.True(done)
+	is.Equal(line, "a\nb")
+}
+
+func TestRenderText(t *testing.T) {
+	is := is.New(t)
+	out := new(bytes.Buffer)
+	e := newTextEditor("? ", 80)
+	e.w = out
+	e.line = []rune("ab\ncd")
+	e.cursor = 1
+	e.refresh()
+	is.Equal(out.String(), "\r\x1b[J? ab\r\ncd\x1b[1A\r\x1b[3C")
+	is.Equal(e.layout.cursorRow, 0)
+
+	out.Reset()
+	e.cursor = 5
+	e.refresh()
+	is.Equal(out.String(), "\r\x1b[J? ab\r\ncd\r\x1b[2C")
+	is.Equal(e.layout.cursorRow, 1)
+
+	out.Reset()
+	e.refresh()
+	is.Equal(out.String(), "\x1b[1A\r\x1b[J? ab\r\ncd\r\x1b[2C")
+}
+
+func TestRenderTextKeepsTextBeforeOrigin(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	e := newTextEditor("? ", 80)
+	e.w = writer
+	e.origin = 5
+	e.line, e.cursor = []rune("a\nb"), 3
+	e.refresh()
+	is.Equal(writer.String(), "\r\x1b[5C\x1b[J? a\r\nb\r\x1b[1C")
+}
@@ -0,0 +1,57 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func TestText(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("first line\nsecond line\n")
+
+	text, err := prompt.Text(ctx, "Message?",
+		prompt.WithReader(reader),
+		prompt.WithWriter(io.Discard),
+	)
+	is.NoErr(err)
+	is.Equal(text, "first line\nsecond line")
+}
+
+func TestTextTerminator(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("first line\n\nthird line\n.\nName\n")
+	withReader := prompt.WithReader(reader)
+	withWriter := prompt.WithWriter(io.Discard)
+
+	text, err := prompt.Text(ctx, "Message?",
+		withReader,
+		withWriter,
+		prompt.WithTerminator("."),
+	)
+	is.NoErr(err)
+	is.Equal(text, "first line\n\nthird line")
+
+	name, err := prompt.Ask(ctx, "Name?", withReader, withWriter)
+	is.NoErr(err)
+	is.Equal(name, "Name")
+}
+
+func TestTextDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	text, err := prompt.Text(ctx, "Message?",
+		prompt.WithReader(bytes.NewBufferString("")),
+		prompt.WithWriter(io.Discard),
+		prompt.WithDefault("hello\nworld"),
+	)
+	is.NoErr(err)
+	is.Equal(text, "hello\nworld")
+}
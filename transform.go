@@ -0,0 +1,57 @@
+package prompt
+
+// WithTransform appends transforms that normalize the input, like trimming
+// whitespace or expanding "~". They run in order before any mask is applied
+// and before the checks, and the transformed input is what's returned. A
+// transform error is shown like a failed check.
+func WithTransform(transforms ...func(input string) (string, error)) Option {
+	return func(q *prompt) {
+		q.transforms = append(q.transforms, transforms...)
+	}
+}
+
+// WithEchoTransform replaces the input on screen with the transformed value
+// when Enter is pressed in a terminal.
+func WithEchoTransform(echo bool) Option {
+	return func(q *prompt) {
+		q.echoTransform = echo
+	}
+}
+
+// transform runs the transforms in order.
+func (q *prompt) transform(input string) (string, error) {
+	for _, transform := range q.transforms {
+		var err error
+		if input, err = transform(input); err != nil {
+			return "", err
+		}
+	}
+	return input, nil
+}
+
+// echoTransformed replaces the line with its transformed value before it's
+// submitted. It reports false if a transform fails, showing the error on the
+// status line.
+func (q *prompt) echoTransformed(e *editor) bool {
+	if !q.echoTransform || len(q.transforms) == 0 {
+		return true
+	}
+	input, err := q.transform(string(e.line))
+	if err != nil {
+		e.setStatus(err)
+		return false
+	}
+	line := []rune(input)
+	if q.mask != nil && input != "" {
+		// The transformed input still has to fill the mask.
+		if line, err = q.mask.parse(input); err != nil {
+			e.setStatus(err)
+			return false
+		}
+	}
+	e.line = line
+	e.cursor = len(e.line)
+	e.refresh()
+	q.transformed = true
+	return true
+}
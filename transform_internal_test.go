@@ -0,0 +1,37 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestEchoTransform(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	q := newPrompt(WithWriter(writer), WithEchoTransform(true), WithTransform(func(s string) (string, error) {
+		if s == "" {
+			return "", errors.New("empty")
+		}
+		return strings.ToUpper(s), nil
+	}))
+	e := newEditor(io.Discard, -1, "? ")
+
+	done, _, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(!done)
+	is.Equal(e.status, "empty")
+
+	e.insert([]rune("abc")...)
+	done, input, err := q.runAction(context.Background(), e, ActionAcceptLine)
+	is.NoErr(err)
+	is.True(done)
+	is.Equal(input, "ABC")
+	is.True(q.transformed)
+	is.Equal(writer.String(), "\a\r\n")
+}
@@ -0,0 +1,64 @@
+package prompt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompt"
+)
+
+func trimSpace(s string) (string, error) {
+	return strings.TrimSpace(s), nil
+}
+
+func lower(s string) (string, error) {
+	return strings.ToLower(s), nil
+}
+
+func TestAskTransform(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	var checked []string
+
+	email, err := prompt.Ask(ctx, "Email?",
+		prompt.WithReader(bytes.NewBufferString("   \n  Gopher@Example.COM \n")),
+		prompt.WithWriter(writer),
+		prompt.WithTransform(trimSpace, lower),
+		prompt.WithCheck(func(s string) error {
+			checked = append(checked, s)
+			return nil
+		}),
+	)
+	is.NoErr(err)
+	is.Equal(email, "gopher@example.com")
+	is.Equal(checked, []string{"gopher@example.com"})
+	is.Equal(writer.String(), "Email? Email? ")
+}
+
+func TestAskTransformError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+
+	dir, err := prompt.Ask(ctx, "Directory?",
+		prompt.WithReader(bytes.NewBufferString("~bob/src\n~/src\n")),
+		prompt.WithWriter(writer),
+		prompt.WithTransform(func(s string) (string, error) {
+			if strings.HasPrefix(s, "~/") {
+				return "/home/gopher" + s[1:], nil
+			}
+			if strings.HasPrefix(s, "~") {
+				return "", errors.New("unknown user")
+			}
+			return s, nil
+		}),
+	)
+	is.NoErr(err)
+	is.Equal(dir, "/home/gopher/src")
+	is.Equal(writer.String(), "Directory? unknown user\nDirectory? ")
+}
@@ -0,0 +1,486 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EditMode selects the key bindings used by the line editor.
+type EditMode int
+
+const (
+	// EmacsMode uses Emacs-style bindings. This is the default.
+	EmacsMode EditMode = iota
+	// ViMode uses vi-style insert and normal modes.
+	ViMode
+)
+
+// WithEditMode sets the key bindings used by the line editor.
+func WithEditMode(mode EditMode) Option {
+	return func(q *prompt) {
+		q.editMode = mode
+	}
+}
+
+// Mode indicators shown in front of the prompt in vi mode.
+const (
+	viInsertIndicator = "(ins) "
+	viNormalIndicator = "(cmd) "
+)
+
+type viMode int
+
+const (
+	viInsert viMode = iota
+	viNormal
+)
+
+// viState is the state of the vi key bindings.
+type viState struct {
+	mode       viMode
+	prompt     string // prompt without the mode indicator
+	count      int    // pending count
+	find       viFind // last f, t, F or T search
+	recording  []byte // keys of the change in progress
+	recCount   int    // count given to the change in progress
+	insertFrom int    // cursor when the change entered insert mode
+	change     *viChange
+	replaying  bool
+}
+
+// viFind is a character search that can be repeated with ; and ,.
+type viFind struct {
+	cmd byte
+	r   rune
+}
+
+// viChange is the last change, repeated with ".".
+type viChange struct {
+	keys  []byte
+	count int
+	text  string // text typed in insert mode, if the change entered it
+}
+
+// startVi shows the insert mode indicator in front of the prompt.
+func (e *editor) startVi() {
+	e.vi.prompt = e.prompt
+	e.setViMode(viInsert)
+}
+
+func (e *editor) setViMode(mode viMode) {
+	e.vi.mode = mode
+	if mode == viNormal {
+		e.setPrompt(viNormalIndicator + e.vi.prompt)
+		return
+	}
+	e.setPrompt(viInsertIndicator + e.vi.prompt)
+}
+
+// handleViKey applies the key starting with b to the editor using vi
+// bindings.
+func (q *prompt) handleViKey(ctx context.Context, e *editor, b byte) (bool, string, error) {
+	if e.vi.mode == viInsert {
+		return q.handleViInsertKey(ctx, e, b)
+	}
+	done, input, err := q.handleViNormalKey(ctx, e, b)
+	if e.vi.mode == viNormal && len(e.line) > 0 && e.cursor >= len(e.line) {
+		e.cursor = prevGrapheme(e.line, len(e.line))
+	}
+	return done, input, err
+}
+
+func (q *prompt) handleViInsertKey(ctx context.Context, e *editor, b byte) (bool, string, error) {
+	if b == 0x1b && !q.escapeSequenceFollows() {
+		q.leaveViInsert(e)
+		return false, "", nil
+	}
+	return q.handleKey(ctx, e, b)
+}
+
+// escapeTimeout is how long to wait for the rest of an escape sequence
+// after Esc, in case the terminal sends it in pieces.
+const escapeTimeout = 50 * time.Millisecond
+
+// escapeSequenceFollows tells a bare Esc apart from the start of an escape
+// sequence like an arrow key.
+func (q *prompt) escapeSequenceFollows() bool {
+	if q.reader.Buffered() == 0 && (q.fd < 0 || !waitReadable(q.fd, escapeTimeout)) {
+		return false
+	}
+	next, err := q.reader.Peek(1)
+	if err != nil {
+		return false
+	}
+	return next[0] == '[' || next[0] == 'O'
+}
+
+// leaveViInsert switches to normal mode, finishing any change that is being
+// recorded for ".".
+func (q *prompt) leaveViInsert(e *editor) {
+	if e.vi.recording != nil && !e.vi.replaying {
+		text := ""
+		if e.vi.insertFrom <= e.cursor && e.cursor <= len(e.line) {
+			text = string(e.line[e.vi.insertFrom:e.cursor])
+		}
+		e.vi.change = &viChange{keys: e.vi.recording, count: e.vi.recCount, text: text}
+	}
+	e.vi.recording = nil
+	e.setViMode(viNormal)
+	e.cursor = prevGrapheme(e.line, e.cursor)
+}
+
+// enterViInsert switches to insert mode at the cursor.
+func (q *prompt) enterViInsert(e *editor) {
+	e.vi.insertFrom = e.cursor
+	e.setViMode(viInsert)
+}
+
+// viByte reads the next key byte, adding it to the change being recorded.
+func (q *prompt) viByte(e *editor) (byte, error) {
+	b, err := q.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if e.vi.recording != nil {
+		e.vi.recording = append(e.vi.recording, b)
+	}
+	return b, nil
+}
+
+// viRune reads the next key as a rune, for character searches.
+func (q *prompt) viRune(e *editor) (rune, error) {
+	b, err := q.viByte(e)
+	if err != nil {
+		return 0, err
+	}
+	if b < 0x80 {
+		return rune(b), nil
+	}
+	buf := []byte{b}
+	for len(buf) < utf8.UTFMax && !utf8.FullRune(buf) {
+		b, err := q.viByte(e)
+		if err != nil {
+			return 0, err
+		}
+		buf = append(buf, b)
+	}
+	r, _ := utf8.DecodeRune(buf)
+	return r, nil
+}
+
+func (q *prompt) handleViNormalKey(ctx context.Context, e *editor, b byte) (bool, string, error) {
+	if (b >= '1' && b <= '9') || (b == '0' && e.vi.count > 0) {
+		e.vi.count = e.vi.count*10 + int(b-'0')
+		return false, "", nil
+	}
+	count := e.vi.count
+	e.vi.count = 0
+	if isViChange(b) && !e.vi.replaying {
+		e.vi.recording = []byte{b}
+		e.vi.recCount = count
+	}
+	if count == 0 {
+		count = 1
+	}
+	var err error
+	switch b {
+	case 0x1b:
+		if q.escapeSequenceFollows() {
+			return q.handleKey(ctx, e, b)
+		}
+	case 0x12: // Ctrl+R
+		e.redo()
+	case 'u':
+		e.undo()
+	case 'i':
+		q.enterViInsert(e)
+	case 'a':
+		e.cursor = nextGrapheme(e.line, e.cursor)
+		q.enterViInsert(e)
+	case 'I':
+		e.cursor = viFirstNonBlank(e.line)
+		q.enterViInsert(e)
+	case 'A':
+		e.cursor = len(e.line)
+		q.enterViInsert(e)
+	case 'x':
+		e.deleteRange(e.cursor, viForward(e.line, e.cursor, count))
+	case 'X':
+		e.deleteRange(viBackward(e.line, e.cursor, count), e.cursor)
+	case 'D':
+		e.deleteRange(e.cursor, len(e.line))
+	case 'C':
+		e.deleteRange(e.cursor, len(e.line))
+		q.enterViInsert(e)
+	case 'd', 'c':
+		err = q.viOperator(e, b, count)
+	case '.':
+		err = q.viRepeat(ctx, e, count)
+	default:
+		if b < 0x20 {
+			return q.handleKey(ctx, e, b)
+		}
+		var target int
+		var ok bool
+		target, _, ok, err = q.viMotion(e, b, count)
+		if ok {
+			e.cursor = target
+		}
+	}
+	if err != nil {
+		return true, "", err
+	}
+	if e.vi.mode == viNormal && !e.vi.replaying {
+		if e.vi.recording != nil {
+			e.vi.change = &viChange{keys: e.vi.recording, count: e.vi.recCount}
+		}
+		e.vi.recording = nil
+	}
+	return false, "", nil
+}
+
+func isViChange(b byte) bool {
+	switch b {
+	case 'x', 'X', 'D', 'C', 'd', 'c', 'i', 'a', 'I', 'A':
+		return true
+	}
+	return false
+}
+
+// viOperator applies d or c to the text covered by the next motion.
+func (q *prompt) viOperator(e *editor, op byte, count int) error {
+	b, err := q.viByte(e)
+	if err != nil {
+		return err
+	}
+	motionCount := 0
+	for (b >= '1' && b <= '9') || (b == '0' && motionCount > 0) {
+		motionCount = motionCount*10 + int(b-'0')
+		if b, err = q.viByte(e); err != nil {
+			return err
+		}
+	}
+	if motionCount > 0 {
+		count *= motionCount
+	}
+	switch {
+	case b == op: // dd, cc
+		e.deleteRange(0, len(e.line))
+	case b == 'w' && op == 'c' && e.cursor < len(e.line) && !unicode.IsSpace(e.line[e.cursor]):
+		// cw changes to the end of the word, like ce.
+		end := e.cursor
+		for i := 0; i < count; i++ {
+			next := nextGrapheme(e.line, end)
+			if i == 0 && (next == len(e.line) || unicode.IsSpace(e.line[next])) {
+				continue
+			}
+			end = viWordEnd(e.line, end)
+		}
+		e.deleteRange(e.cursor, nextGrapheme(e.line, end))
+	default:
+		target, inclusive, ok, err := q.viMotion(e, b, count)
+		if err != nil || !ok {
+			e.vi.recording = nil
+			return err
+		}
+		start, end := e.cursor, target
+		if start > end {
+			start, end = end, start
+		} else if inclusive {
+			end = nextGrapheme(e.line, end)
+		}
+		e.deleteRange(start, end)
+	}
+	if op == 'c' {
+		q.enterViInsert(e)
+	}
+	return nil
+}
+
+// viMotion returns where the motion b moves the cursor and whether the
+// character under the target is included when used with an operator.
+func (q *prompt) viMotion(e *editor, b byte, count int) (target int, inclusive, ok bool, err error) {
+	line, cursor := e.line, e.cursor
+	switch b {
+	case 'h', 0x7f, 0x08:
+		return viBackward(line, cursor, count), false, true, nil
+	case 'l', ' ':
+		return viForward(line, cursor, count), false, true, nil
+	case 'w':
+		for i := 0; i < count; i++ {
+			cursor = viWordStart(line, cursor)
+		}
+		return cursor, false, true, nil
+	case 'b':
+		for i := 0; i < count; i++ {
+			cursor = moveCursorWordLeft(line, cursor)
+		}
+		return cursor, false, true, nil
+	case 'e':
+		for i := 0; i < count; i++ {
+			cursor = viWordEnd(line, cursor)
+		}
+		return cursor, true, true, nil
+	case '0':
+		return 0, false, true, nil
+	case '^':
+		return viFirstNonBlank(line), false, true, nil
+	case '$':
+		return len(line), false, true, nil
+	case 'f', 't', 'F', 'T':
+		r, err := q.viRune(e)
+		if err != nil {
+			return 0, false, false, err
+		}
+		e.vi.find = viFind{cmd: b, r: r}
+		target, ok := viFindChar(line, cursor, b, r, count, false)
+		return target, b == 'f' || b == 't', ok, nil
+	case ';', ',':
+		find := e.vi.find
+		if find.cmd == 0 {
+			return 0, false, false, nil
+		}
+		cmd := find.cmd
+		if b == ',' {
+			cmd = reverseViFind(cmd)
+		}
+		target, ok := viFindChar(line, cursor, cmd, find.r, count, true)
+		return target, cmd == 'f' || cmd == 't', ok, nil
+	}
+	return 0, false, false, nil
+}
+
+// viRepeat replays the last change, optionally with a new count.
+func (q *prompt) viRepeat(ctx context.Context, e *editor, count int) error {
+	change := e.vi.change
+	if change == nil {
+		return nil
+	}
+	if count <= 1 {
+		count = change.count
+	}
+	reader := q.reader
+	q.reader = bufio.NewReader(bytes.NewReader(change.keys[1:]))
+	e.vi.replaying = true
+	defer func() {
+		q.reader = reader
+		e.vi.replaying = false
+	}()
+	e.vi.count = count
+	if _, _, err := q.handleViNormalKey(ctx, e, change.keys[0]); err != nil {
+		return err
+	}
+	if e.vi.mode == viInsert {
+		e.insert([]rune(change.text)...)
+		q.leaveViInsert(e)
+	}
+	return nil
+}
+
+// deleteRange removes line[start:end] and moves the cursor to start.
+func (e *editor) deleteRange(start, end int) {
+	if start >= end {
+		return
+	}
+	e.line = append(e.line[:start], e.line[end:]...)
+	e.cursor = start
+}
+
+func viForward(line []rune, cursor, count int) int {
+	for i := 0; i < count && cursor < len(line); i++ {
+		cursor = nextGrapheme(line, cursor)
+	}
+	return cursor
+}
+
+func viBackward(line []rune, cursor, count int) int {
+	for i := 0; i < count && cursor > 0; i++ {
+		cursor = prevGrapheme(line, cursor)
+	}
+	return cursor
+}
+
+// viWordStart returns the start of the next word.
+func viWordStart(line []rune, cursor int) int {
+	for cursor < len(line) && !unicode.IsSpace(line[cursor]) {
+		cursor = nextGrapheme(line, cursor)
+	}
+	for cursor < len(line) && unicode.IsSpace(line[cursor]) {
+		cursor = nextGrapheme(line, cursor)
+	}
+	return cursor
+}
+
+// viWordEnd returns the last character of the current or next word.
+func viWordEnd(line []rune, cursor int) int {
+	end := moveCursorWordRight(line, nextGrapheme(line, cursor))
+	return prevGrapheme(line, end)
+}
+
+func viFirstNonBlank(line []rune) int {
+	cursor := 0
+	for cursor < len(line) && unicode.IsSpace(line[cursor]) {
+		cursor = nextGrapheme(line, cursor)
+	}
+	return cursor
+}
+
+// viFindChar searches for the count-th r from the cursor. f and t search
+// forward, F and T backward, and t and T stop next to the match. When t or T
+// is repeated, a match next to the cursor is skipped so the cursor moves.
+func viFindChar(line []rune, cursor int, cmd byte, r rune, count int, repeat bool) (int, bool) {
+	i := cursor
+	if repeat {
+		switch cmd {
+		case 't':
+			i = nextGrapheme(line, cursor)
+		case 'T':
+			i = prevGrapheme(line, cursor)
+		}
+	}
+	for n := 0; n < count; n++ {
+		found := false
+		if cmd == 'f' || cmd == 't' {
+			for i = nextGrapheme(line, i); i < len(line); i = nextGrapheme(line, i) {
+				if line[i] == r {
+					found = true
+					break
+				}
+			}
+		} else {
+			for i > 0 {
+				i = prevGrapheme(line, i)
+				if line[i] == r {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return cursor, false
+		}
+	}
+	switch cmd {
+	case 't':
+		i = prevGrapheme(line, i)
+	case 'T':
+		i = nextGrapheme(line, i)
+	}
+	return i, true
+}
+
+func reverseViFind(cmd byte) byte {
+	switch cmd {
+	case 'f':
+		return 'F'
+	case 'F':
+		return 'f'
+	case 't':
+		return 'T'
+	}
+	return 't'
+}
@@ -0,0 +1,197 @@
+package prompt
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func newViEditor(line string) *editor {
+	e := newEditor(io.Discard, -1, "? ")
+	e.startVi()
+	e.line, e.cursor = []rune(line), len([]rune(line))
+	return e
+}
+
+func TestViInsertAndEscape(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("")
+
+	typeKeys(t, e, "hello\x1b", WithEditMode(ViMode))
+	is.Equal(string(e.line), "hello")
+	is.Equal(e.cursor, 4)
+	is.Equal(e.vi.mode, viNormal)
+	is.Equal(e.prompt, "(cmd) ? ")
+
+	typeKeys(t, e, "0xA!", WithEditMode(ViMode))
+	is.Equal(string(e.line), "ello!")
+	is.Equal(e.vi.mode, viInsert)
+	is.Equal(e.prompt, "(ins) ? ")
+}
+
+func TestViMotions(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("one two three")
+	typeKeys(t, e, "\x1b0", WithEditMode(ViMode))
+	is.Equal(e.cursor, 0)
+
+	typeKeys(t, e, "w", WithEditMode(ViMode))
+	is.Equal(e.cursor, 4)
+	typeKeys(t, e, "e", WithEditMode(ViMode))
+	is.Equal(e.cursor, 6)
+	typeKeys(t, e, "b", WithEditMode(ViMode))
+	is.Equal(e.cursor, 4)
+	typeKeys(t, e, "$", WithEditMode(ViMode))
+	is.Equal(e.cursor, 12)
+	typeKeys(t, e, "0l2l", WithEditMode(ViMode))
+	is.Equal(e.cursor, 3)
+	typeKeys(t, e, "h", WithEditMode(ViMode))
+	is.Equal(e.cursor, 2)
+	typeKeys(t, e, "0 2w", WithEditMode(ViMode))
+	is.Equal(e.cursor, 8)
+}
+
+func TestViDelete(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("one two three four")
+	typeKeys(t, e, "\x1b0dw", WithEditMode(ViMode))
+	is.Equal(string(e.line), "two three four")
+
+	typeKeys(t, e, "d2w", WithEditMode(ViMode))
+	is.Equal(string(e.line), "four")
+
+	typeKeys(t, e, "2x", WithEditMode(ViMode))
+	is.Equal(string(e.line), "ur")
+
+	typeKeys(t, e, "dd", WithEditMode(ViMode))
+	is.Equal(string(e.line), "")
+}
+
+func TestViDeleteToEnd(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("hello world")
+	typeKeys(t, e, "\x1b0wD", WithEditMode(ViMode))
+	is.Equal(string(e.line), "hello ")
+	is.Equal(e.cursor, 5)
+}
+
+func TestViChange(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("hello world")
+	typeKeys(t, e, "\x1b0cwbye\x1b", WithEditMode(ViMode))
+	is.Equal(string(e.line), "bye world")
+	is.Equal(e.cursor, 2)
+
+	typeKeys(t, e, "wCfriends\x1b", WithEditMode(ViMode))
+	is.Equal(string(e.line), "bye friends")
+}
+
+func TestViFindChar(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("a-b-c-d")
+	typeKeys(t, e, "\x1b0f-", WithEditMode(ViMode))
+	is.Equal(e.cursor, 1)
+	typeKeys(t, e, ";", WithEditMode(ViMode))
+	is.Equal(e.cursor, 3)
+	typeKeys(t, e, ",", WithEditMode(ViMode))
+	is.Equal(e.cursor, 1)
+	typeKeys(t, e, "tc", WithEditMode(ViMode))
+	is.Equal(e.cursor, 3)
+	typeKeys(t, e, "Fa", WithEditMode(ViMode))
+	is.Equal(e.cursor, 0)
+	typeKeys(t, e, "dt-", WithEditMode(ViMode))
+	is.Equal(string(e.line), "-b-c-d")
+	typeKeys(t, e, "$dFb", WithEditMode(ViMode))
+	is.Equal(string(e.line), "-d")
+}
+
+func TestViFindCharRepeatTill(t *testing.T) {
+	is := is.New(t)
+	// Repeating a till motion skips the match next to the cursor.
+	e := newViEditor("a,b,c")
+	typeKeys(t, e, "\x1b0t,", WithEditMode(ViMode))
+	is.Equal(e.cursor, 0)
+	typeKeys(t, e, ";", WithEditMode(ViMode))
+	is.Equal(e.cursor, 2)
+	typeKeys(t, e, ";", WithEditMode(ViMode))
+	is.Equal(e.cursor, 2)
+
+	e = newViEditor("a,b,c")
+	typeKeys(t, e, "\x1b$T,", WithEditMode(ViMode))
+	is.Equal(e.cursor, 4)
+	typeKeys(t, e, ";", WithEditMode(ViMode))
+	is.Equal(e.cursor, 2)
+	typeKeys(t, e, ";", WithEditMode(ViMode))
+	is.Equal(e.cursor, 2)
+	typeKeys(t, e, ",", WithEditMode(ViMode))
+	is.Equal(e.cursor, 2)
+
+	// A count finds distinct matches.
+	e = newViEditor("ab")
+	typeKeys(t, e, "\x1b$2Fa", WithEditMode(ViMode))
+	is.Equal(e.cursor, 1)
+}
+
+func TestViSplitEscapeSequence(t *testing.T) {
+	if !canPoll {
+		t.Skip("waiting for input isn't supported on this platform")
+	}
+	is := is.New(t)
+	r, w, err := os.Pipe()
+	is.NoErr(err)
+	defer r.Close()
+	defer w.Close()
+	q := newPrompt(WithReader(r), WithWriter(io.Discard))
+
+	// The rest of an arrow key arrives after the Esc has been read, so it
+	// isn't buffered yet.
+	_, err = w.Write([]byte("\x1b"))
+	is.NoErr(err)
+	_, err = q.reader.ReadByte()
+	is.NoErr(err)
+	is.Equal(q.reader.Buffered(), 0)
+	_, err = w.Write([]byte("[A"))
+	is.NoErr(err)
+	is.True(q.escapeSequenceFollows())
+
+	// A bare Esc is recognized once the wait is over.
+	_, err = q.reader.Discard(2)
+	is.NoErr(err)
+	is.True(!q.escapeSequenceFollows())
+}
+
+func TestViRepeat(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("one two three four")
+	typeKeys(t, e, "\x1b0dw.", WithEditMode(ViMode))
+	is.Equal(string(e.line), "three four")
+
+	typeKeys(t, e, "cwx\x1bw.", WithEditMode(ViMode))
+	is.Equal(string(e.line), "x x")
+
+	e = newViEditor("abcdef")
+	typeKeys(t, e, "\x1b02x.", WithEditMode(ViMode))
+	is.Equal(string(e.line), "ef")
+	typeKeys(t, e, "u", WithEditMode(ViMode))
+	is.Equal(string(e.line), "cdef")
+}
+
+func TestViUndo(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("keep this")
+	typeKeys(t, e, "\x1bddu", WithEditMode(ViMode))
+	is.Equal(string(e.line), "keep this")
+
+	typeKeys(t, e, "\x12", WithEditMode(ViMode))
+	is.Equal(string(e.line), "")
+}
+
+func TestViEscapeSequenceInInsertMode(t *testing.T) {
+	is := is.New(t)
+	e := newViEditor("ab")
+	typeKeys(t, e, "\x1b[Dx", WithEditMode(ViMode))
+	is.Equal(string(e.line), "axb")
+	is.Equal(e.vi.mode, viInsert)
+}